@@ -0,0 +1,32 @@
+package zeropool
+
+import "unsafe"
+
+// NewAlignedPool creates a new Pool of byte slices of the given size, whose first byte is aligned to align bytes,
+// like the buffers needed for O_DIRECT or io_uring, and the given options.
+// The alignment is only guaranteed if the slices are put back into the pool without moving their start,
+// re-slicing them like buf[:n] is fine, but buf[n:] is not.
+// It panics if align is not a power of two.
+func NewAlignedPool(size, align int, opts ...Option[[]byte]) Pool[[]byte] {
+	checkAlignment(align)
+	return New(func() []byte { return AlignedSlice(size, align) }, opts...)
+}
+
+// AlignedSlice allocates a byte slice of the given size, whose first byte is aligned to align bytes.
+// It panics if align is not a power of two.
+func AlignedSlice(size, align int) []byte {
+	checkAlignment(align)
+	buf := make([]byte, size+align)
+	offset := 0
+	if rem := int(uintptr(unsafe.Pointer(unsafe.SliceData(buf))) & uintptr(align-1)); rem != 0 {
+		offset = align - rem
+	}
+	return buf[offset : offset+size : offset+size]
+}
+
+// checkAlignment panics if align is not a power of two.
+func checkAlignment(align int) {
+	if align <= 0 || align&(align-1) != 0 {
+		panic("zeropool: alignment must be a power of two")
+	}
+}
@@ -0,0 +1,39 @@
+package zeropool_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/colega/zeropool"
+)
+
+func TestAlignedPool(t *testing.T) {
+	t.Run("provides aligned slices", func(t *testing.T) {
+		for _, align := range []int{1, 8, 512, 4096} {
+			pool := zeropool.NewAlignedPool(1000, align)
+			for i := 0; i < 10; i++ {
+				buf := pool.Get()
+				assertEqual(t, 1000, len(buf))
+				assertEqual(t, 1000, cap(buf))
+				assertAligned(t, buf, align)
+				pool.Put(buf)
+			}
+		}
+	})
+
+	t.Run("panics if alignment is not a power of two", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic.")
+			}
+		}()
+		zeropool.NewAlignedPool(1000, 1000)
+	})
+}
+
+func assertAligned(t *testing.T, buf []byte, align int) {
+	t.Helper()
+	if addr := uintptr(unsafe.Pointer(unsafe.SliceData(buf))); addr%uintptr(align) != 0 {
+		t.Errorf("Expected address %x to be aligned to %d bytes.", addr, align)
+	}
+}
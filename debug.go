@@ -0,0 +1,18 @@
+package zeropool
+
+import (
+	"log"
+	"reflect"
+	"unsafe"
+)
+
+// largeItemSize is the size of T above which debug builds suggest using a PointerPool instead of a Pool.
+const largeItemSize = 1024
+
+// warnIfLarge logs a suggestion to use a PointerPool if T is expensive to copy by value.
+func warnIfLarge[T any]() {
+	var zero T
+	if size := unsafe.Sizeof(zero); size > largeItemSize {
+		log.Printf("zeropool: Pool[%s] copies %d bytes on each Get and Put, consider using a PointerPool instead", reflect.TypeOf((*T)(nil)).Elem(), size)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !zeropool_debug
+
+package zeropool
+
+// debug is true when the package is built with the zeropool_debug build tag, which enables extra checks.
+const debug = false
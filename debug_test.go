@@ -0,0 +1,24 @@
+package zeropool
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestWarnIfLarge(t *testing.T) {
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&out)
+
+	warnIfLarge[[largeItemSize]byte]()
+	if out.Len() != 0 {
+		t.Errorf("Expected no warning for an item of %d bytes, got %q.", largeItemSize, out.String())
+	}
+
+	warnIfLarge[[largeItemSize + 1]byte]()
+	if !strings.Contains(out.String(), "consider using a PointerPool") {
+		t.Errorf("Expected a warning for an item of %d bytes, got %q.", largeItemSize+1, out.String())
+	}
+}
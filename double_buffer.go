@@ -0,0 +1,49 @@
+package zeropool
+
+import "sync"
+
+// DoubleBuffer implements the "fill one buffer while flushing the other" pattern on top of a Pool.
+// Writers update the active buffer with Update, while a flusher takes it with Swap,
+// which replaces it with a fresh one from the pool,
+// and puts it back into the pool with Release once it's flushed.
+type DoubleBuffer[T any] struct {
+	pool *Pool[T]
+
+	mtx    sync.Mutex
+	active T
+}
+
+// NewDoubleBuffer creates a new DoubleBuffer[T], taking its first active buffer from pool.
+func NewDoubleBuffer[T any](pool *Pool[T]) *DoubleBuffer[T] {
+	return &DoubleBuffer[T]{
+		pool:   pool,
+		active: pool.Get(),
+	}
+}
+
+// Update calls f with the active buffer, and stores the buffer it returns as the active one.
+// The buffer must not be retained by f after it returns.
+// Update may be called concurrently from multiple goroutines, f is called while holding a lock.
+func (d *DoubleBuffer[T]) Update(f func(T) T) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.active = f(d.active)
+}
+
+// Swap returns the active buffer, replacing it by a new one from the pool.
+// The caller owns the returned buffer, and should pass it to Release once it's done with it.
+// Swap may be called concurrently from multiple goroutines.
+func (d *DoubleBuffer[T]) Swap() T {
+	next := d.pool.Get()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	prev := d.active
+	d.active = next
+	return prev
+}
+
+// Release puts a buffer returned by Swap back into the pool.
+func (d *DoubleBuffer[T]) Release(buf T) {
+	d.pool.Put(buf)
+}
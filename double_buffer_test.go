@@ -0,0 +1,39 @@
+package zeropool_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestDoubleBuffer(t *testing.T) {
+	pool := zeropool.New(func() []byte { return make([]byte, 0, 1024) })
+	db := zeropool.NewDoubleBuffer(&pool)
+
+	const writers, writes = 10, 1000
+	wg := sync.WaitGroup{}
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				db.Update(func(buf []byte) []byte { return append(buf, 'x') })
+			}
+		}()
+	}
+
+	flushed := 0
+	flush := func() {
+		buf := db.Swap()
+		flushed += len(buf)
+		db.Release(buf[:0])
+	}
+	for i := 0; i < 100; i++ {
+		flush()
+	}
+	wg.Wait()
+	flush()
+
+	assertEqual(t, writers*writes, flushed)
+}
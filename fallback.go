@@ -0,0 +1,64 @@
+package zeropool
+
+// Interface is implemented by the pools of this package that hand out items of type T,
+// so they can be composed without knowing their concrete type.
+type Interface[T any] interface {
+	// Get returns an item from the pool, creating a new one if necessary.
+	Get() T
+	// TryGet returns an item from the pool, and false if the pool is empty, in which case no item is created.
+	TryGet() (T, bool)
+	// Put adds an item to the pool.
+	Put(T)
+}
+
+var (
+	_ Interface[int]  = (*Pool[int])(nil)
+	_ Interface[*int] = (*PointerPool[int])(nil)
+	_ Interface[int]  = (*LRUPool[int])(nil)
+	_ Interface[int]  = (*FallbackPool[int])(nil)
+)
+
+// FallbackPool chains two pools: Get tries the primary pool first, and falls back to the secondary one if it's empty.
+// It's meant to combine a warm local pool with a shared one.
+type FallbackPool[T any] struct {
+	primary, secondary Interface[T]
+	putPrimary         func(T) bool
+}
+
+// Fallback creates a new FallbackPool[T] that gets items from primary, or from secondary if primary is empty.
+// Put adds the items to primary if putPrimary returns true for them, and to secondary otherwise.
+// If putPrimary is nil, all the items are put into primary.
+func Fallback[T any](primary, secondary Interface[T], putPrimary func(T) bool) *FallbackPool[T] {
+	return &FallbackPool[T]{
+		primary:    primary,
+		secondary:  secondary,
+		putPrimary: putPrimary,
+	}
+}
+
+// Get returns an item from the primary pool if it's not empty, or from the secondary pool otherwise,
+// which creates a new one if necessary.
+func (p *FallbackPool[T]) Get() T {
+	if item, ok := p.primary.TryGet(); ok {
+		return item
+	}
+	return p.secondary.Get()
+}
+
+// TryGet returns an item from the primary pool, or from the secondary pool,
+// and false if both are empty, in which case no item is created.
+func (p *FallbackPool[T]) TryGet() (T, bool) {
+	if item, ok := p.primary.TryGet(); ok {
+		return item, true
+	}
+	return p.secondary.TryGet()
+}
+
+// Put adds an item to the pool chosen by the put policy.
+func (p *FallbackPool[T]) Put(item T) {
+	if p.putPrimary == nil || p.putPrimary(item) {
+		p.primary.Put(item)
+		return
+	}
+	p.secondary.Put(item)
+}
@@ -0,0 +1,42 @@
+package zeropool_test
+
+import (
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestFallback(t *testing.T) {
+	t.Run("gets from primary first", func(t *testing.T) {
+		skipIfDisabled(t)
+		primary := zeropool.NewLRUPool(10, func() string { return "new primary" }, nil)
+		secondary := zeropool.NewLRUPool(10, func() string { return "new secondary" }, nil)
+		pool := zeropool.Fallback[string](&primary, &secondary, nil)
+
+		secondary.Put("secondary")
+		primary.Put("primary")
+		assertEqual(t, "primary", pool.Get())
+		assertEqual(t, "secondary", pool.Get())
+		assertEqual(t, "new secondary", pool.Get())
+
+		_, ok := pool.TryGet()
+		assertEqual(t, false, ok)
+	})
+
+	t.Run("puts by policy", func(t *testing.T) {
+		skipIfDisabled(t)
+		primary := zeropool.NewLRUPool[[]byte](10, nil, nil)
+		secondary := zeropool.NewLRUPool[[]byte](10, nil, nil)
+		pool := zeropool.Fallback[[]byte](&primary, &secondary, func(b []byte) bool { return cap(b) <= 1024 })
+
+		pool.Put(make([]byte, 1024))
+		pool.Put(make([]byte, 4096))
+
+		item, ok := primary.TryGet()
+		assertEqual(t, true, ok)
+		assertEqual(t, 1024, cap(item))
+		item, ok = secondary.TryGet()
+		assertEqual(t, true, ok)
+		assertEqual(t, 4096, cap(item))
+	})
+}
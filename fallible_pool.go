@@ -0,0 +1,81 @@
+package zeropool
+
+import "context"
+
+// FalliblePool is a pool of items whose construction can fail, like items that need I/O to be created.
+// It works like Pool, but its Get returns the error from the function that creates the items.
+//
+// Zero value of FalliblePool[T] is valid, and it will return zero values of T if nothing is pooled.
+type FalliblePool[T any] struct {
+	pool Pool[T]
+	item func(ctx context.Context) (T, error)
+}
+
+// NewFallible creates a new FalliblePool[T] with the given function to create new items, and the given options.
+// A FalliblePool must not be copied after first use.
+func NewFallible[T any](item func() (T, error), opts ...Option[T]) FalliblePool[T] {
+	var itemCtx func(context.Context) (T, error)
+	if item != nil {
+		itemCtx = func(context.Context) (T, error) { return item() }
+	}
+	return NewFallibleCtx(itemCtx, opts...)
+}
+
+// NewFallibleCtx creates a new FalliblePool[T] with the given context-aware function to create new items, and the given options.
+// The function receives the context passed to GetCtx, so it can respect its deadline and cancellation.
+// A FalliblePool must not be copied after first use.
+func NewFallibleCtx[T any](item func(ctx context.Context) (T, error), opts ...Option[T]) FalliblePool[T] {
+	return FalliblePool[T]{
+		pool: New[T](nil, opts...),
+		item: item,
+	}
+}
+
+// Get returns an item from the pool, creating a new one if necessary.
+// If creating the item fails, the error is returned.
+// Get may be called concurrently from multiple goroutines.
+func (p *FalliblePool[T]) Get() (T, error) {
+	return p.GetCtx(context.Background())
+}
+
+// GetCtx returns an item from the pool, creating a new one with the given context if necessary.
+// If the context is already done, or it's done while waiting for WithNewConcurrency to allow creating an item,
+// a new item is not created, and the context's error is returned instead.
+// When WithBatchNew is used, creating the extra items stops at the first one that fails, as the caller already has its item.
+// GetCtx may be called concurrently from multiple goroutines.
+func (p *FalliblePool[T]) GetCtx(ctx context.Context) (item T, err error) {
+	if p.pool.getPooledInto(&item) || p.item == nil {
+		return item, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return item, err
+	}
+	if slots := p.pool.newSlots; slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return item, ctx.Err()
+		}
+		defer func() { <-slots }()
+		// Someone may have put an item back while we were waiting.
+		if p.pool.getPooledInto(&item) {
+			return item, nil
+		}
+	}
+	if item, err = p.item(ctx); err != nil {
+		return item, err
+	}
+	for i := 1; i < p.pool.opts.batchNew && !disabled; i++ {
+		extra, err := p.item(ctx)
+		if err != nil {
+			break
+		}
+		p.pool.Put(extra)
+	}
+	return item, nil
+}
+
+// Put adds an item to the pool.
+func (p *FalliblePool[T]) Put(item T) {
+	p.pool.Put(item)
+}
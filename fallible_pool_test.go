@@ -0,0 +1,129 @@
+package zeropool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colega/zeropool"
+)
+
+func TestFalliblePool(t *testing.T) {
+	t.Run("returns constructor errors", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		fail := true
+		pool := zeropool.NewFallible(func() ([]byte, error) {
+			if fail {
+				return nil, errFailed
+			}
+			return make([]byte, 1024), nil
+		})
+
+		_, err := pool.Get()
+		assertEqual(t, errFailed, err)
+
+		fail = false
+		item, err := pool.Get()
+		assertEqual(t, nil, err)
+		assertEqual(t, 1024, len(item))
+		pool.Put(item)
+
+		fail = true
+		for i := 0; i < 10; i++ {
+			// We might get the pooled item or an error, if sync.Pool dropped it.
+			item, err := pool.Get()
+			if err == nil {
+				assertEqual(t, 1024, len(item))
+				pool.Put(item)
+			}
+		}
+	})
+
+	t.Run("passes the context to the constructor", func(t *testing.T) {
+		type ctxKey struct{}
+		pool := zeropool.NewFallibleCtx(func(ctx context.Context) (string, error) {
+			return ctx.Value(ctxKey{}).(string), nil
+		})
+
+		item, err := pool.GetCtx(context.WithValue(context.Background(), ctxKey{}, "value"))
+		assertEqual(t, nil, err)
+		assertEqual(t, "value", item)
+	})
+
+	t.Run("does not create items when context is done", func(t *testing.T) {
+		pool := zeropool.NewFallibleCtx(func(ctx context.Context) ([]byte, error) {
+			t.Errorf("Constructor should not be called.")
+			return nil, nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pool.GetCtx(ctx)
+		assertEqual(t, context.Canceled, err)
+	})
+
+	t.Run("waits for WithNewConcurrency until the context is done", func(t *testing.T) {
+		started := make(chan struct{})
+		unblock := make(chan struct{})
+		pool := zeropool.NewFallible(func() ([]byte, error) {
+			close(started)
+			<-unblock
+			return make([]byte, 1024), nil
+		}, zeropool.WithNewConcurrency[[]byte](1))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			item, err := pool.Get()
+			assertEqual(t, nil, err)
+			assertEqual(t, 1024, len(item))
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := pool.GetCtx(ctx)
+		assertEqual(t, context.DeadlineExceeded, err)
+
+		close(unblock)
+		<-done
+	})
+
+	t.Run("creates batches with WithBatchNew", func(t *testing.T) {
+		var created int
+		pool := zeropool.NewFallible(func() (int, error) {
+			created++
+			return created, nil
+		}, zeropool.WithBatchNew[int](10))
+
+		item, err := pool.Get()
+		assertEqual(t, nil, err)
+		assertEqual(t, 1, item)
+		if zeropool.Disabled() {
+			assertEqualf(t, 1, created, "Should not create a batch when pooling is disabled.")
+		} else {
+			assertEqual(t, 10, created)
+		}
+	})
+
+	t.Run("zero value is valid", func(t *testing.T) {
+		var pool zeropool.FalliblePool[[]byte]
+		item, err := pool.Get()
+		assertEqual(t, nil, err)
+		pool.Put(item)
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.NewFallible(func() ([]byte, error) { return make([]byte, 1024), nil })
+		item, _ := pool.Get()
+		pool.Put(item)
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			item, _ := pool.Get()
+			pool.Put(item)
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
+}
@@ -0,0 +1,49 @@
+package zeropool
+
+import "sync"
+
+// Intern deduplicates equal values of T to a canonical instance.
+// Unlike the pools, it doesn't recycle values: it's meant for read-mostly values, like label strings,
+// where reusing the same instance is what saves the allocations.
+//
+// Zero value of Intern[T] is valid, and it holds an unlimited number of values.
+type Intern[T comparable] struct {
+	// limit is the maximum number of values held, 0 means no limit.
+	limit int
+
+	mtx    sync.RWMutex
+	values map[T]T
+}
+
+// NewIntern creates a new Intern[T] that holds up to limit values, 0 means no limit.
+// An Intern must not be copied after first use.
+func NewIntern[T comparable](limit int) Intern[T] {
+	return Intern[T]{limit: limit}
+}
+
+// Intern returns the canonical instance of v.
+// If v wasn't seen before, v becomes the canonical instance, unless the maximum number of values is reached,
+// in which case v is returned without being held.
+// Intern may be called concurrently from multiple goroutines.
+func (in *Intern[T]) Intern(v T) T {
+	in.mtx.RLock()
+	canonical, ok := in.values[v]
+	in.mtx.RUnlock()
+	if ok {
+		return canonical
+	}
+
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+	if canonical, ok := in.values[v]; ok {
+		return canonical
+	}
+	if in.limit > 0 && len(in.values) >= in.limit {
+		return v
+	}
+	if in.values == nil {
+		in.values = map[T]T{}
+	}
+	in.values[v] = v
+	return v
+}
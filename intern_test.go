@@ -0,0 +1,31 @@
+package zeropool_test
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/colega/zeropool"
+)
+
+func TestIntern(t *testing.T) {
+	t.Run("returns canonical instances", func(t *testing.T) {
+		var in zeropool.Intern[string]
+		first := in.Intern(strings.Repeat("a", 10))
+		second := in.Intern(strings.Repeat("a", 10))
+		assertEqual(t, first, second)
+		assertEqualf(t, unsafe.StringData(first), unsafe.StringData(second), "Should return the same instance.")
+	})
+
+	t.Run("does not hold more than max values", func(t *testing.T) {
+		in := zeropool.NewIntern[string](1)
+		in.Intern("a")
+
+		first := in.Intern(strings.Repeat("b", 10))
+		second := in.Intern(strings.Repeat("b", 10))
+		assertEqual(t, first, second)
+		if unsafe.StringData(first) == unsafe.StringData(second) {
+			t.Errorf("Should not intern values over the limit.")
+		}
+	})
+}
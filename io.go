@@ -0,0 +1,162 @@
+package zeropool
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// ErrClosed is returned when using a ReadCloser or a WriteCloser after closing it.
+var ErrClosed = errors.New("zeropool: use of closed reader or writer")
+
+// ReadCloser is an io.ReadCloser that reads from a pooled buffer, and puts it back into the pool when closed.
+// It's meant to be returned up the stack to transfer the ownership of the pooled buffer:
+// whoever closes it is done with the data.
+type ReadCloser struct {
+	pool   *Pool[[]byte]
+	buf    []byte
+	r      bytes.Reader
+	closed bool
+}
+
+// NewReadCloser returns a ReadCloser reading buf, which will be put back into pool on Close.
+// The caller must not use buf after calling NewReadCloser.
+func NewReadCloser(pool *Pool[[]byte], buf []byte) *ReadCloser {
+	rc := &ReadCloser{pool: pool, buf: buf}
+	rc.r.Reset(buf)
+	return rc
+}
+
+// Read implements io.Reader.
+func (rc *ReadCloser) Read(p []byte) (int, error) {
+	if rc.closed {
+		return 0, ErrClosed
+	}
+	return rc.r.Read(p)
+}
+
+// Close puts the buffer back into the pool.
+// Calling Close more than once returns an error.
+func (rc *ReadCloser) Close() error {
+	if rc.closed {
+		return ErrClosed
+	}
+	rc.closed = true
+	rc.r.Reset(nil)
+	rc.pool.Put(rc.buf[:0])
+	rc.buf = nil
+	return nil
+}
+
+// WriteCloser is an io.WriteCloser that buffers the written data in a pooled buffer,
+// writes it to the underlying io.Writer on Close, and puts the buffer back into the pool.
+type WriteCloser struct {
+	pool   *Pool[[]byte]
+	buf    []byte
+	w      io.Writer
+	closed bool
+}
+
+// NewWriteCloser returns a WriteCloser that writes to w using a buffer from pool.
+func NewWriteCloser(pool *Pool[[]byte], w io.Writer) *WriteCloser {
+	return &WriteCloser{pool: pool, buf: pool.Get()[:0], w: w}
+}
+
+// Write implements io.Writer.
+// The data is only written to the underlying io.Writer on Close.
+func (wc *WriteCloser) Write(p []byte) (int, error) {
+	if wc.closed {
+		return 0, ErrClosed
+	}
+	wc.buf = append(wc.buf, p...)
+	return len(p), nil
+}
+
+// Close writes the buffered data to the underlying io.Writer, and puts the buffer back into the pool,
+// even if the write fails.
+// Calling Close more than once returns an error.
+func (wc *WriteCloser) Close() error {
+	if wc.closed {
+		return ErrClosed
+	}
+	wc.closed = true
+	_, err := wc.w.Write(wc.buf)
+	wc.pool.Put(wc.buf[:0])
+	wc.buf = nil
+	return err
+}
+
+// ScannerBuffer sets a buffer from pool as the initial buffer of s, with the given max token size, see bufio.Scanner.Buffer.
+// The returned release func puts the buffer back into the pool,
+// it must be called once the scanning is done, and neither s nor the tokens it returned can be used after that.
+// Like bufio.Scanner.Buffer, it panics if it's called after the scanning has started.
+//
+// If the scanner needed a larger buffer, it allocates it and drops the pooled one,
+// but bufio.Scanner doesn't expose its buffer, so the grown one can't be pooled.
+func ScannerBuffer(s *bufio.Scanner, pool *Pool[[]byte], max int) (release func()) {
+	buf := pool.Get()
+	s.Buffer(buf[:cap(buf)], max)
+	return func() { pool.Put(buf[:0]) }
+}
+
+// ReadAll reads from r until an error or io.EOF into a buffer from pool, like io.ReadAll.
+// It's meant to read request and response bodies without allocating a new buffer for each one.
+// The returned release func puts the buffer back into the pool, the returned bytes must not be used after calling it.
+// If the read fails, the buffer is returned to the pool immediately, and release is a no-op.
+//
+// When the pooled buffer is too small, it's grown like append does, and the one from the pool is put back into it,
+// while the intermediate ones are dropped, so a single large read doesn't fill the pool with buffers of every size.
+func ReadAll(pool *Pool[[]byte], r io.Reader) (data []byte, release func(), err error) {
+	buf := pool.Get()[:0]
+	pooled := buf
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+			if cap(pooled) > 0 {
+				pool.Put(pooled[:0])
+				pooled = nil
+			}
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, func() { pool.Put(buf[:0]) }, nil
+		}
+		if err != nil {
+			pool.Put(buf[:0])
+			return nil, func() {}, err
+		}
+	}
+}
+
+// BytesReader is a bytes.Reader with a scratch buffer, handed out by a BytesReaderPool.
+// It's meant for decoding loops, where each message needs a reader over its data, and some scratch space to decode it.
+type BytesReader struct {
+	bytes.Reader
+	// Scratch is an empty buffer that the decoder can append to, its capacity is preserved across uses.
+	Scratch []byte
+}
+
+// BytesReaderPool is a pool of BytesReaders.
+//
+// Zero value of BytesReaderPool is valid.
+type BytesReaderPool struct {
+	pool PointerPool[BytesReader]
+}
+
+// Get returns a BytesReader from the pool reading data.
+// Get may be called concurrently from multiple goroutines.
+func (p *BytesReaderPool) Get(data []byte) *BytesReader {
+	r := p.pool.Get()
+	r.Reset(data)
+	return r
+}
+
+// Put adds a BytesReader to the pool, keeping its scratch buffer, but not the data it was reading.
+// Neither the reader nor its scratch buffer can be used after calling Put.
+func (p *BytesReaderPool) Put(r *BytesReader) {
+	r.Reset(nil)
+	r.Scratch = r.Scratch[:0]
+	p.pool.Put(r)
+}
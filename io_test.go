@@ -0,0 +1,140 @@
+package zeropool_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/colega/zeropool"
+)
+
+func TestReadCloser(t *testing.T) {
+	pool := zeropool.New(func() []byte { return make([]byte, 0, 1024) })
+	buf := append(pool.Get(), "hello"...)
+
+	rc := zeropool.NewReadCloser(&pool, buf)
+	data, err := io.ReadAll(rc)
+	assertEqual(t, nil, err)
+	assertEqual(t, "hello", string(data))
+
+	assertEqual(t, nil, rc.Close())
+	if err := rc.Close(); !errors.Is(err, zeropool.ErrClosed) {
+		t.Errorf("Expected ErrClosed when closing twice, got %v.", err)
+	}
+	if _, err := rc.Read(make([]byte, 1)); !errors.Is(err, zeropool.ErrClosed) {
+		t.Errorf("Expected ErrClosed when reading after close, got %v.", err)
+	}
+}
+
+func TestWriteCloser(t *testing.T) {
+	pool := zeropool.New(func() []byte { return make([]byte, 0, 1024) })
+	var out bytes.Buffer
+
+	wc := zeropool.NewWriteCloser(&pool, &out)
+	_, err := io.WriteString(wc, "hello ")
+	assertEqual(t, nil, err)
+	_, err = io.WriteString(wc, "world")
+	assertEqual(t, nil, err)
+	assertEqualf(t, 0, out.Len(), "Should not write before Close.")
+
+	assertEqual(t, nil, wc.Close())
+	assertEqual(t, "hello world", out.String())
+
+	if err := wc.Close(); !errors.Is(err, zeropool.ErrClosed) {
+		t.Errorf("Expected ErrClosed when closing twice, got %v.", err)
+	}
+	if _, err := wc.Write([]byte("x")); !errors.Is(err, zeropool.ErrClosed) {
+		t.Errorf("Expected ErrClosed when writing after close, got %v.", err)
+	}
+}
+
+func TestScannerBuffer(t *testing.T) {
+	pool := zeropool.New(func() []byte { return make([]byte, 0, 1024) })
+
+	scanner := bufio.NewScanner(strings.NewReader("one\ntwo\nthree\n"))
+	release := zeropool.ScannerBuffer(scanner, &pool, 4096)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	assertEqual(t, nil, scanner.Err())
+	assertEqual(t, []string{"one", "two", "three"}, lines)
+	release()
+
+	buf := pool.Get()
+	assertEqual(t, 1024, cap(buf))
+}
+
+func TestReadAll(t *testing.T) {
+	t.Run("reads everything", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 0, 16) })
+		input := strings.Repeat("hello world ", 100)
+
+		data, release, err := zeropool.ReadAll(&pool, strings.NewReader(input))
+		assertEqual(t, nil, err)
+		assertEqual(t, input, string(data))
+		release()
+	})
+
+	t.Run("pools only the original buffer when growing", func(t *testing.T) {
+		skipIfDisabled(t)
+		var puts int
+		pool := zeropool.New(
+			func() []byte { return make([]byte, 0, 16) },
+			zeropool.WithPutPolicy(func(b []byte) ([]byte, bool) {
+				puts++
+				return b, true
+			}),
+		)
+
+		data, release, err := zeropool.ReadAll(&pool, bytes.NewReader(make([]byte, 64<<10)))
+		assertEqual(t, nil, err)
+		assertEqual(t, 64<<10, len(data))
+		assertEqualf(t, 1, puts, "Should only put back the buffer taken from the pool.")
+
+		release()
+		assertEqual(t, 2, puts)
+	})
+
+	t.Run("returns read errors", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 0, 16) })
+		readErr := errors.New("read failed")
+
+		data, release, err := zeropool.ReadAll(&pool, io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(readErr)))
+		if !errors.Is(err, readErr) {
+			t.Errorf("Expected the read error, got %v.", err)
+		}
+		assertEqual(t, 0, len(data))
+		release()
+	})
+}
+
+func TestBytesReaderPool(t *testing.T) {
+	skipIfDisabled(t)
+	var pool zeropool.BytesReaderPool
+
+	r := pool.Get([]byte("hello"))
+	data, err := io.ReadAll(r)
+	assertEqual(t, nil, err)
+	assertEqual(t, "hello", string(data))
+	r.Scratch = append(r.Scratch, "scratch"...)
+	pool.Put(r)
+
+	r = pool.Get([]byte("world"))
+	data, err = io.ReadAll(r)
+	assertEqual(t, nil, err)
+	assertEqual(t, "world", string(data))
+	assertEqualf(t, 0, len(r.Scratch), "Should hand out empty scratch buffers.")
+	pool.Put(r)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		r := pool.Get(data)
+		r.Scratch = append(r.Scratch, data...)
+		pool.Put(r)
+	})
+	assertEqualf(t, float64(0), allocs, "Should not allocate.")
+}
@@ -0,0 +1,95 @@
+package zeropool
+
+import "sync"
+
+// LRUPool is a bounded pool of items that evicts the least recently used item when it's full.
+// Get always returns the most recently used item, so it's meant for items where recency predicts their value,
+// like compiled regular expressions or dictionaries.
+// Unlike Pool, the items held by LRUPool are not released by the garbage collector,
+// so every item that leaves it without being handed out goes through its evict function,
+// which makes it suitable for items holding resources that must be released explicitly, like file descriptors.
+//
+// Zero value of LRUPool[T] is valid, but it has no capacity, so it will never hold any items.
+type LRUPool[T any] struct {
+	item func() T
+	// evict is called with each item that the pool drops instead of handing out, it can be nil.
+	evict func(T)
+
+	mtx sync.Mutex
+	// items is a ring buffer holding count items,
+	// starting from items[oldest], which is the least recently used one.
+	items  []T
+	oldest int
+	count  int
+}
+
+// NewLRUPool creates a new LRUPool[T] holding up to size items, with the given function to create new items,
+// and the given function to release the items that are evicted, or dropped by Put because the pool can't hold them.
+// Both item and evict can be nil.
+// An LRUPool must not be copied after first use.
+func NewLRUPool[T any](size int, item func() T, evict func(T)) LRUPool[T] {
+	return LRUPool[T]{
+		item:  item,
+		evict: evict,
+		items: make([]T, size),
+	}
+}
+
+// Get returns the most recently used item from the pool, creating a new one if necessary.
+// Get may be called concurrently from multiple goroutines.
+func (p *LRUPool[T]) Get() T {
+	if item, ok := p.TryGet(); ok {
+		return item
+	}
+	if p.item != nil {
+		return p.item()
+	}
+	var zero T
+	return zero
+}
+
+// TryGet returns the most recently used item from the pool, and false if the pool is empty, in which case no item is created.
+// TryGet may be called concurrently from multiple goroutines.
+func (p *LRUPool[T]) TryGet() (item T, ok bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.count == 0 {
+		return item, false
+	}
+	p.count--
+	i := (p.oldest + p.count) % len(p.items)
+	item = p.items[i]
+	var zero T
+	p.items[i] = zero
+	return item, true
+}
+
+// Put adds an item to the pool, evicting the least recently used one if the pool is full.
+// The evict function is called with the evicted item, or with item itself if the pool can't hold any items,
+// after the pool is unlocked, so it can be slow.
+func (p *LRUPool[T]) Put(item T) {
+	if evicted, ok := p.put(item); ok && p.evict != nil {
+		p.evict(evicted)
+	}
+}
+
+// put adds an item to the pool, and returns the item that had to be dropped for it, if any.
+func (p *LRUPool[T]) put(item T) (evicted T, ok bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if len(p.items) == 0 || disabled {
+		return item, true
+	}
+	if p.count == len(p.items) {
+		// Overwrite the least recently used item, the next one becomes the oldest.
+		evicted = p.items[p.oldest]
+		p.items[p.oldest] = item
+		p.oldest = (p.oldest + 1) % len(p.items)
+		return evicted, true
+	}
+	p.items[(p.oldest+p.count)%len(p.items)] = item
+	p.count++
+	return evicted, false
+}
@@ -0,0 +1,61 @@
+package zeropool_test
+
+import (
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestLRUPool(t *testing.T) {
+	t.Run("returns most recently used items first", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.NewLRUPool(2, func() int { return -1 }, nil)
+		pool.Put(1)
+		pool.Put(2)
+		pool.Put(3) // Evicts 1.
+
+		assertEqual(t, 3, pool.Get())
+		pool.Put(4)
+		assertEqual(t, 4, pool.Get())
+		assertEqual(t, 2, pool.Get())
+		assertEqual(t, -1, pool.Get())
+	})
+
+	t.Run("calls evict with the dropped items", func(t *testing.T) {
+		skipIfDisabled(t)
+		var evicted []int
+		pool := zeropool.NewLRUPool(2, func() int { return -1 }, func(item int) {
+			evicted = append(evicted, item)
+		})
+		pool.Put(1)
+		pool.Put(2)
+		pool.Put(3) // Evicts 1.
+		pool.Put(4) // Evicts 2.
+		assertEqual(t, []int{1, 2}, evicted)
+
+		var empty zeropool.LRUPool[int]
+		empty.Put(5)
+		assertEqual(t, []int{1, 2}, evicted)
+		sizeless := zeropool.NewLRUPool(0, nil, func(item int) { evicted = append(evicted, item) })
+		sizeless.Put(5)
+		assertEqualf(t, []int{1, 2, 5}, evicted, "Should evict items that can't be held.")
+	})
+
+	t.Run("zero value is valid", func(t *testing.T) {
+		var pool zeropool.LRUPool[[]byte]
+		pool.Put(make([]byte, 1024))
+		assertEqual(t, 0, len(pool.Get()))
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.NewLRUPool(10, func() []byte { return make([]byte, 1024) }, nil)
+		pool.Put(pool.Get())
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			item := pool.Get()
+			pool.Put(item)
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
+}
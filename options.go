@@ -0,0 +1,86 @@
+package zeropool
+
+// Option configures a Pool created with New.
+type Option[T any] func(*options[T])
+
+// options holds the configuration of a Pool.
+type options[T any] struct {
+	// healthy reports whether a pooled item can be handed out, see WithHealthCheck.
+	healthy func(T) bool
+	// batchNew is the number of items created when the pool is empty, see WithBatchNew.
+	batchNew int
+	// newConcurrency is the maximum number of items created concurrently, see WithNewConcurrency.
+	newConcurrency int
+	// putPolicy transforms the items before pooling them, or rejects them, see WithPutPolicy.
+	putPolicy func(T) (T, bool)
+	// simple makes the pool allocate a new pointer for each item put, see WithSimpleMode.
+	simple bool
+}
+
+// addPutPolicy adds a put policy that runs after the ones already configured.
+func (o *options[T]) addPutPolicy(policy func(T) (T, bool)) {
+	prev := o.putPolicy
+	if prev == nil {
+		o.putPolicy = policy
+		return
+	}
+	o.putPolicy = func(item T) (T, bool) {
+		item, ok := prev(item)
+		if !ok {
+			return item, false
+		}
+		return policy(item)
+	}
+}
+
+// WithHealthCheck makes Get check the pooled items with the given function before handing them out.
+// Items for which healthy returns false are dropped, and Get tries with the next one, creating a new one if necessary.
+// New items are not checked.
+func WithHealthCheck[T any](healthy func(T) bool) Option[T] {
+	return func(o *options[T]) {
+		o.healthy = healthy
+	}
+}
+
+// WithBatchNew makes Get create n items at once when the pool is empty, returning one of them and pooling the rest.
+// It amortizes the cost of the misses when they come in bursts, like right after the pool was cleared by a GC.
+func WithBatchNew[T any](n int) Option[T] {
+	return func(o *options[T]) {
+		o.batchNew = n
+	}
+}
+
+// WithNewConcurrency limits the number of items that can be created concurrently to n.
+// When the pool is empty and n items are already being created, Get waits until one of them is created,
+// and then takes an item from the pool if someone put one back meanwhile, or creates a new one otherwise.
+// It prevents a burst of misses from running a thundering herd of expensive constructors.
+func WithNewConcurrency[T any](n int) Option[T] {
+	return func(o *options[T]) {
+		o.newConcurrency = n
+	}
+}
+
+// WithSimpleMode makes the pool allocate a new pointer to store each item put, instead of recycling them.
+// Recycling the pointers costs two extra sync.Pool operations per Get and Put,
+// which for tiny items, like integers or small structs, can cost more than the allocation it avoids.
+func WithSimpleMode[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.simple = true
+	}
+}
+
+// WithMinCap makes Put drop the slices with a capacity smaller than n, instead of pooling them.
+// It prevents tiny slices, like the ones from error paths, from being handed out to callers who would have to grow them anyway.
+func WithMinCap[E any](n int) Option[[]E] {
+	return WithPutPolicy(func(s []E) ([]E, bool) { return s, cap(s) >= n })
+}
+
+// WithPutPolicy makes Put pass the items through policy before pooling them.
+// The policy can normalize an item, like truncating it or resetting its fields, by returning the modified item and true,
+// or reject it by returning false, in which case the item is dropped.
+// When several put policies are configured, they run in the order they were provided, until one of them rejects the item.
+func WithPutPolicy[T any](policy func(T) (T, bool)) Option[T] {
+	return func(o *options[T]) {
+		o.addPutPolicy(policy)
+	}
+}
@@ -0,0 +1,140 @@
+package zeropool_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/colega/zeropool"
+)
+
+func TestWithHealthCheck(t *testing.T) {
+	pool := zeropool.New(
+		func() int { return 100 },
+		zeropool.WithHealthCheck(func(i int) bool { return i > 0 }),
+	)
+
+	pool.Put(1)
+	pool.Put(-1)
+	pool.Put(-2)
+	for i := 0; i < 3; i++ {
+		if item := pool.Get(); item <= 0 {
+			t.Errorf("Expected a healthy item, got %d.", item)
+		}
+	}
+}
+
+func TestWithBatchNew(t *testing.T) {
+	var created int
+	pool := zeropool.New(
+		func() int {
+			created++
+			return created
+		},
+		zeropool.WithBatchNew[int](10),
+	)
+
+	assertEqual(t, 1, pool.Get())
+	if zeropool.Disabled() {
+		assertEqualf(t, 1, created, "Should not create a batch when pooling is disabled.")
+		return
+	}
+	assertEqual(t, 10, created)
+	// The rest of the batch is pooled, although sync.Pool may drop some of them.
+	if item := pool.Get(); item > 10 {
+		t.Errorf("Expected an item from the batch, got %d.", item)
+	}
+}
+
+func TestWithNewConcurrency(t *testing.T) {
+	var mtx sync.Mutex
+	var running, maxRunning int
+	create := func() []byte {
+		mtx.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mtx.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mtx.Lock()
+		running--
+		mtx.Unlock()
+		return make([]byte, 1024)
+	}
+
+	for name, get := range map[string]func(*zeropool.Pool[[]byte]) []byte{
+		"Get":     func(pool *zeropool.Pool[[]byte]) []byte { return pool.Get() },
+		"GetWith": func(pool *zeropool.Pool[[]byte]) []byte { return pool.GetWith(create) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			maxRunning = 0
+			pool := zeropool.New(create, zeropool.WithNewConcurrency[[]byte](2))
+
+			const concurrency = 20
+			wg := sync.WaitGroup{}
+			wg.Add(concurrency)
+			for i := 0; i < concurrency; i++ {
+				go func() {
+					defer wg.Done()
+					assertEqual(t, 1024, len(get(&pool)))
+				}()
+			}
+			wg.Wait()
+
+			if maxRunning > 2 {
+				t.Errorf("Expected at most 2 concurrent constructors, got %d.", maxRunning)
+			}
+		})
+	}
+}
+
+func TestWithMinCap(t *testing.T) {
+	pool := zeropool.NewSlicePool(zeropool.WithMinCap[byte](1024))
+	pool.Put(make([]byte, 10))
+	assertEqualf(t, 0, cap(pool.GetAppendable()), "Should not pool slices smaller than min capacity.")
+
+	pool.Put(make([]byte, 10, 1024))
+	if c := cap(pool.GetAppendable()); c != 0 && c != 1024 {
+		t.Errorf("Expected capacity 0 or 1024, got %d", c)
+	}
+}
+
+func TestWithPutPolicy(t *testing.T) {
+	pool := zeropool.New(
+		func() []byte { return make([]byte, 0, 1024) },
+		zeropool.WithPutPolicy(func(b []byte) ([]byte, bool) { return b[:0], true }),
+		zeropool.WithPutPolicy(func(b []byte) ([]byte, bool) { return b, cap(b) <= 4096 }),
+	)
+
+	pool.Put(make([]byte, 100, 1024))
+	pool.Put(make([]byte, 100, 1<<20))
+	for i := 0; i < 3; i++ {
+		b := pool.Get()
+		assertEqualf(t, 0, len(b), "Put policy should truncate the items.")
+		if cap(b) > 4096 {
+			t.Errorf("Put policy should reject big items, got capacity %d.", cap(b))
+		}
+	}
+}
+
+func TestWithSimpleMode(t *testing.T) {
+	skipIfDisabled(t)
+	pool := zeropool.New(
+		func() int { return -1 },
+		zeropool.WithSimpleMode[int](),
+	)
+
+	pool.Put(1)
+	if item := pool.Get(); item != 1 && item != -1 {
+		t.Errorf("Expected the pooled item or a new one, got %d.", item)
+	}
+
+	// Each Put allocates the pointer to store the item, but Get doesn't allocate.
+	allocs := testing.AllocsPerRun(1000, func() {
+		pool.Put(pool.Get())
+	})
+	assertEqualf(t, float64(1), allocs, "Should allocate once per Put.")
+}
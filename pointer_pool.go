@@ -0,0 +1,72 @@
+package zeropool
+
+// PointerPool is a type-safe pool of pointers to items.
+// Unlike Pool, it hands out *T directly, so the items are never copied.
+// It's meant to be used when T is a large struct, and copying it by value on Get and Put would be the dominant cost.
+//
+// Zero value of PointerPool[T] is valid, and it will return new(T) if nothing is pooled.
+type PointerPool[T any] struct {
+	// item creates new items when the pool is empty, new(T) is used when it's nil.
+	item  func() *T
+	items syncPool
+	// reset is called on each item before putting it back into the pool, it can be nil.
+	reset func(*T)
+}
+
+// NewPointerPool creates a new PointerPool[T] with the given function to create new items,
+// and the given function to reset items before they're pooled again.
+// Both item and reset can be nil, in which case new(T) is used to create items and they aren't reset.
+// A PointerPool must not be copied after first use.
+func NewPointerPool[T any](item func() *T, reset func(*T)) PointerPool[T] {
+	return PointerPool[T]{
+		item:  item,
+		reset: reset,
+	}
+}
+
+// Get returns an item from the pool, creating a new one if necessary.
+// Get may be called concurrently from multiple goroutines.
+func (p *PointerPool[T]) Get() *T {
+	if item, ok := p.TryGet(); ok {
+		return item
+	}
+	if p.item != nil {
+		return p.item()
+	}
+	return new(T)
+}
+
+// TryGet returns an item from the pool, and false if the pool is empty, in which case no item is created.
+// TryGet may be called concurrently from multiple goroutines.
+func (p *PointerPool[T]) TryGet() (*T, bool) {
+	if pooled := p.items.Get(); pooled != nil {
+		return pooled.(*T), true
+	}
+	return nil, false
+}
+
+// Put resets the item and adds it to the pool.
+// The item must not be used after calling Put.
+func (p *PointerPool[T]) Put(item *T) {
+	if item == nil || disabled {
+		return
+	}
+	if p.reset != nil {
+		p.reset(item)
+	}
+	p.items.Put(item)
+}
+
+// NewResetPool creates a new PointerPool[T] that calls the Reset method of the items before pooling them again.
+// It's meant for types that already know how to reset themselves, like bytes.Buffer or generated protobuf messages,
+// without this package depending on them:
+//
+//	pool := zeropool.NewResetPool[pb.Request]()
+//
+// New items are created with new(T).
+func NewResetPool[T any, P interface {
+	*T
+	Reset()
+}]() PointerPool[T] {
+	return NewPointerPool[T](nil, func(item *T) { P(item).Reset() })
+}
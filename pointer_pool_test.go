@@ -0,0 +1,81 @@
+package zeropool_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+type largeStruct struct {
+	data [4096]byte
+	used int
+}
+
+func TestPointerPool(t *testing.T) {
+	t.Run("provides correct values", func(t *testing.T) {
+		pool := zeropool.NewPointerPool(
+			func() *largeStruct { return &largeStruct{used: -1} },
+			func(s *largeStruct) { s.used = 0 },
+		)
+
+		item := pool.Get()
+		assertEqual(t, -1, item.used)
+		item.used = 10
+		item.data[0] = 1
+		pool.Put(item)
+
+		item = pool.Get()
+		if item.used != 0 && item.used != -1 {
+			t.Errorf("Expected item to be reset or new, got used=%d", item.used)
+		}
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.NewPointerPool(func() *largeStruct { return new(largeStruct) }, nil)
+		// Warm up, this will allocate one item.
+		item := pool.Get()
+		pool.Put(item)
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			item := pool.Get()
+			pool.Put(item)
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
+
+	t.Run("zero value is valid", func(t *testing.T) {
+		var pool zeropool.PointerPool[largeStruct]
+		item := pool.Get()
+		if item == nil {
+			t.Fatalf("Expected non-nil item.")
+		}
+		pool.Put(item)
+	})
+}
+
+func BenchmarkPointerPool(b *testing.B) {
+	pool := zeropool.NewPointerPool(func() *largeStruct { return new(largeStruct) }, nil)
+
+	// Warmup
+	item := pool.Get()
+	pool.Put(item)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		item := pool.Get()
+		pool.Put(item)
+	}
+}
+
+func TestNewResetPool(t *testing.T) {
+	pool := zeropool.NewResetPool[bytes.Buffer]()
+	buf := pool.Get()
+	buf.WriteString("hello")
+	pool.Put(buf)
+
+	for i := 0; i < 3; i++ {
+		assertEqualf(t, 0, pool.Get().Len(), "Should reset the buffers.")
+	}
+}
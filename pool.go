@@ -1,6 +1,10 @@
 package zeropool
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
 
 // Pool is a type-safe pool of items that does not allocate pointers to items.
 // That is not entirely true, it does allocate sometimes, but not most of the time,
@@ -10,53 +14,231 @@ import "sync"
 //
 // Zero value of Pool[T] is valid, and it will return zero values of T if nothing is pooled.
 type Pool[T any] struct {
+	// item creates new items when the pool is empty, it's nil for the zero value of Pool.
+	item func() T
 	// items holds pointers to the pooled items, which are valid to be used.
-	items sync.Pool
+	items syncPool
 	// pointers holds just pointers to the pooled item types.
 	// The values referenced by pointers are not valid to be used (as they're used by some other caller)
 	// and it is safe to overwrite these pointers.
-	pointers sync.Pool
+	pointers syncPool
+	// sharedPointers is used instead of pointers when it's not nil.
+	// Pools created with New share it with all the other pools of the same type T.
+	sharedPointers *syncPool
+	// frozen is set by Freeze.
+	frozen atomic.Bool
+	// opts holds the options the pool was created with.
+	opts options[T]
+	// newSlots limits the number of concurrent calls to item when it's not nil, see WithNewConcurrency.
+	newSlots chan struct{}
 }
 
-// New creates a new Pool[T] with the given function to create new items.
+// sharedPointersByType holds a *syncPool of pointers for each type T, see sharedPointersPool.
+var sharedPointersByType sync.Map
+
+// sharedPointersPool returns the pool of pointers shared by all the pools of T created with New,
+// so warming it up and keeping its pointers is paid once per type, instead of once per pool.
+// It's safe to share them because the values referenced by those pointers are never valid to be used.
+func sharedPointersPool[T any]() *syncPool {
+	key := reflect.TypeOf((*T)(nil))
+	if pool, ok := sharedPointersByType.Load(key); ok {
+		return pool.(*syncPool)
+	}
+	pool, _ := sharedPointersByType.LoadOrStore(key, new(syncPool))
+	return pool.(*syncPool)
+}
+
+// New creates a new Pool[T] with the given function to create new items, and the given options.
+// A Pool must not be copied after first use.
+func New[T any](item func() T, opts ...Option[T]) Pool[T] {
+	var o options[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if debug {
+		warnIfLarge[T]()
+	}
+	return newPool(item, sharedPointersPool[T](), o)
+}
+
+// NewFromPrototype creates a new Pool[T] that creates new items by cloning proto with the given function.
+// It's meant for items whose initial state is expensive to compute, but cheap to copy.
+// The clone function must return a deep copy, as the new items must not share any state with proto.
 // A Pool must not be copied after first use.
-func New[T any](item func() T) Pool[T] {
+func NewFromPrototype[T any](proto T, clone func(T) T, opts ...Option[T]) Pool[T] {
+	return New(func() T { return clone(proto) }, opts...)
+}
+
+// Clone returns a new empty Pool[T] that creates items with the same function and options as p.
+// It's useful to stamp out several pools from a single configured one.
+func (p *Pool[T]) Clone() Pool[T] {
+	return newPool(p.item, p.sharedPointers, p.opts)
+}
+
+// newPool creates a new Pool[T] with the given configuration.
+func newPool[T any](item func() T, sharedPointers *syncPool, opts options[T]) Pool[T] {
+	var newSlots chan struct{}
+	if opts.newConcurrency > 0 {
+		newSlots = make(chan struct{}, opts.newConcurrency)
+	}
 	return Pool[T]{
-		items: sync.Pool{
-			New: func() interface{} {
-				val := item()
-				return &val
-			},
-		},
+		item:           item,
+		sharedPointers: sharedPointers,
+		opts:           opts,
+		newSlots:       newSlots,
 	}
 }
 
 // Get returns an item from the pool, creating a new one if necessary.
 // Get may be called concurrently from multiple goroutines.
-func (p *Pool[T]) Get() T {
-	pooled := p.items.Get()
-	if pooled == nil {
-		// The only way this can happen is when someone is using the zero-value of zeropool.Pool, and items pool is empty.
-		// We don't have a pointer to store in p.pointers, so just return the empty value.
+func (p *Pool[T]) Get() (item T) {
+	p.GetInto(&item)
+	return item
+}
+
+// GetInto writes an item from the pool into dst, creating a new one if necessary.
+// It's equivalent to *dst = p.Get(), but it skips the intermediate copy through the return value,
+// which can be noticeable when T is a big array-backed struct.
+// GetInto may be called concurrently from multiple goroutines.
+func (p *Pool[T]) GetInto(dst *T) {
+	if !p.getPooledInto(dst) {
+		p.newInto(dst, p.item)
+	}
+}
+
+// GetWith returns an item from the pool, calling item to create one if the pool is empty,
+// instead of the function the pool was created with.
+// It's meant for items whose initial value depends on the caller, while any pooled item is fine for it.
+// The options of the pool apply to item as well, but the extra items created by WithBatchNew use the pool's function.
+// GetWith may be called concurrently from multiple goroutines.
+func (p *Pool[T]) GetWith(item func() T) (got T) {
+	if !p.getPooledInto(&got) {
+		p.newInto(&got, item)
+	}
+	return got
+}
+
+// GetFresh returns an item from the pool, creating a new one if necessary,
+// and true if the returned item is a new one instead of a pooled one.
+// It lets callers skip the initialization that recycled items don't need, and tests assert that the items are reused.
+// GetFresh may be called concurrently from multiple goroutines.
+func (p *Pool[T]) GetFresh() (item T, fresh bool) {
+	if p.getPooledInto(&item) {
+		return item, false
+	}
+	return item, p.newInto(&item, p.item)
+}
+
+// TryGet returns an item from the pool, and false if the pool is empty, in which case no item is created.
+// TryGet may be called concurrently from multiple goroutines.
+func (p *Pool[T]) TryGet() (item T, ok bool) {
+	ok = p.getPooledInto(&item)
+	return item, ok
+}
+
+// getPooledInto writes a pooled item into dst, it returns false if there are no pooled items.
+func (p *Pool[T]) getPooledInto(dst *T) bool {
+	for {
+		pooled := p.items.Get()
+		if pooled == nil {
+			return false
+		}
+
+		ptr := pooled.(*T)
+		*dst = *ptr
+		// In simple mode the pointer is not recycled, so it's left to the garbage collector.
+		if !p.opts.simple {
+			var zero T
+			// We don't want to retain the value in p.pointers.
+			// If T holds a reference to something, we want that to be garbage-collected
+			// if for some reason caller does less Put() calls than Get() calls.
+			*ptr = zero
+			p.pointersPool().Put(ptr)
+		}
+
+		if p.opts.healthy == nil || p.opts.healthy(*dst) {
+			return true
+		}
+		// The item is not healthy: drop it and try with the next one.
+	}
+}
+
+// newInto writes a new item created with item into dst, which is the zero value of T if item is nil.
+// It returns false if it found a pooled item to write into dst instead.
+func (p *Pool[T]) newInto(dst *T, item func() T) (fresh bool) {
+	if item == nil {
+		// Someone is using the zero-value of zeropool.Pool, and items pool is empty, so just return the empty value.
 		var zero T
-		return zero
+		*dst = zero
+		return true
+	}
+	if p.frozen.Load() {
+		panic("zeropool: new item requested from a frozen pool")
+	}
+	if p.newSlots != nil {
+		p.newSlots <- struct{}{}
+		defer func() { <-p.newSlots }()
+		// Someone may have put an item back while we were waiting.
+		if p.getPooledInto(dst) {
+			return false
+		}
 	}
+	*dst = item()
+	if disabled {
+		// The extra items would be dropped by Put anyway.
+		return true
+	}
+	for i := 1; i < p.opts.batchNew && p.item != nil; i++ {
+		p.Put(p.item())
+	}
+	return true
+}
 
-	ptr := pooled.(*T)
-	item := *ptr
-	var zero T
-	// We don't want to retain the value in p.pointers.
-	// If T holds a reference to something, we want that to be garbage-collected
-	// if for some reason caller does less Put() calls than Get() calls.
-	*ptr = zero
-	p.pointers.Put(ptr)
-	return item
+// Freeze makes any later Get that would need to create a new item panic.
+// It's meant for tests and benchmarks asserting that, once the pool is warmed up, no new items are created.
+// Note that sync.Pool may drop items on GC, or randomly when the race detector is enabled.
+func (p *Pool[T]) Freeze() {
+	p.frozen.Store(true)
 }
 
 // Put adds an item to the pool.
 func (p *Pool[T]) Put(item T) {
+	p.put(p.pointersPool(), item)
+}
+
+// Swap puts item into the pool and returns an item from it, which may be the same one.
+// It's meant for loops that exchange their working item at each iteration:
+// item must not be used after calling Swap, only the returned one.
+// Swap may be called concurrently from multiple goroutines.
+func (p *Pool[T]) Swap(item T) T {
+	p.Put(item)
+	return p.Get()
+}
+
+// PutAll adds all the items to the pool, it's equivalent to calling Put for each one of them.
+// The items slice itself is not retained, so it can be reused by the caller.
+func (p *Pool[T]) PutAll(items []T) {
+	pointers := p.pointersPool()
+	for _, item := range items {
+		p.put(pointers, item)
+	}
+}
+
+// put adds an item to the pool, storing it in a pointer taken from pointers.
+func (p *Pool[T]) put(pointers *syncPool, item T) {
+	if disabled {
+		return
+	}
+	if p.opts.putPolicy != nil {
+		var ok bool
+		if item, ok = p.opts.putPolicy(item); !ok {
+			return
+		}
+	}
 	var ptr *T
-	if pooled := p.pointers.Get(); pooled != nil {
+	if p.opts.simple {
+		ptr = new(T)
+	} else if pooled := pointers.Get(); pooled != nil {
 		ptr = pooled.(*T)
 	} else {
 		ptr = new(T)
@@ -64,3 +246,22 @@ func (p *Pool[T]) Put(item T) {
 	*ptr = item
 	p.items.Put(ptr)
 }
+
+// Invalidate discards all the items currently pooled, so they're not handed out by later calls to Get.
+// It's meant to be used when the items pooled so far are no longer valid, like after a configuration change.
+// The items that are checked out when Invalidate is called will still be pooled when they're put back,
+// a put policy can be used to reject them if they can be told apart.
+// The items put concurrently with Invalidate may be discarded too.
+func (p *Pool[T]) Invalidate() {
+	// Get steals from every P, so this drains the items put by every goroutine.
+	for p.items.Get() != nil {
+	}
+}
+
+// pointersPool returns the pool of pointers that p should use.
+func (p *Pool[T]) pointersPool() *syncPool {
+	if p.sharedPointers != nil {
+		return p.sharedPointers
+	}
+	return &p.pointers
+}
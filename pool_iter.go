@@ -0,0 +1,21 @@
+//go:build go1.23
+
+package zeropool
+
+import "iter"
+
+// All returns an iterator that drains the pool, yielding the pooled items until the pool is empty.
+// The yielded items are taken out of the pool, so they're owned by the caller, who may put them back once done with them,
+// but not while ranging over All, as they would be yielded again.
+// If the loop stops early, the remaining items stay pooled.
+// No new items are created, and sync.Pool may have dropped some of the items put, so All doesn't yield all the items ever put.
+func (p *Pool[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, ok := p.TryGet()
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package zeropool_test
+
+import (
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestPoolAll(t *testing.T) {
+	t.Run("drains the pool", func(t *testing.T) {
+		pool := zeropool.New(func() int { return -1 })
+		for i := 0; i < 10; i++ {
+			pool.Put(i)
+		}
+
+		for item := range pool.All() {
+			if item < 0 || item >= 10 {
+				t.Errorf("Expected a pooled item, got %d.", item)
+			}
+		}
+		_, ok := pool.TryGet()
+		assertEqualf(t, false, ok, "Should drain the pool.")
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.New(func() int { return -1 })
+		for i := 0; i < 10; i++ {
+			pool.Put(i)
+		}
+
+		for range pool.All() {
+			break
+		}
+		// Only one item was taken, so at least one of the other nine must still be pooled.
+		_, ok := pool.TryGet()
+		assertEqualf(t, true, ok, "Should leave the rest of the items pooled.")
+	})
+}
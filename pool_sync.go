@@ -0,0 +1,8 @@
+//go:build !tinygo
+
+package zeropool
+
+import "sync"
+
+// syncPool is the pool that backs all the pools in this package.
+type syncPool = sync.Pool
@@ -66,6 +66,7 @@ func TestPool(t *testing.T) {
 	})
 
 	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
 		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
 		// Warm up, this will alloate one slice.
 		slice := pool.Get()
@@ -89,6 +90,179 @@ func TestPool(t *testing.T) {
 		})
 		assertEqualf(t, float64(0), allocs, "Should not allocate.")
 	})
+
+	t.Run("pools of the same type share pointers", func(t *testing.T) {
+		type item struct{ buf []byte }
+		pool1 := zeropool.New(func() item { return item{} })
+		pool2 := zeropool.New(func() item { return item{} })
+
+		// Each pool1.Get() releases the pointer that stored the item into the shared pointers pool.
+		pool1.PutAll(make([]item, 1000))
+		for {
+			if _, ok := pool1.TryGet(); !ok {
+				break
+			}
+		}
+
+		// pool2.Put() should reuse the pointers released by pool1,
+		// even if the race detector made sync.Pool drop some of them, there should be enough left.
+		allocs := testing.AllocsPerRun(100, func() {
+			pool2.Put(item{})
+		})
+		assertEqualf(t, float64(0), allocs, "Should reuse the pointers released by another pool.")
+	})
+
+	t.Run("Clone creates items with the same function and options", func(t *testing.T) {
+		pool := zeropool.New(
+			func() []byte { return make([]byte, 1024) },
+			zeropool.WithPutPolicy(func(b []byte) ([]byte, bool) { return b, len(b) == 1024 }),
+		)
+		pool.Put(make([]byte, 1024))
+
+		clone := pool.Clone()
+		_, ok := clone.TryGet()
+		assertEqualf(t, false, ok, "Clone should start empty.")
+		assertEqual(t, 1024, len(clone.Get()))
+
+		clone.Put(make([]byte, 10))
+		_, ok = clone.TryGet()
+		assertEqualf(t, false, ok, "Clone should have the same put policy.")
+
+		pool.TryGet()
+		clone.Put(make([]byte, 1024))
+		_, ok = pool.TryGet()
+		assertEqualf(t, false, ok, "Clone should not share the pooled items with the original pool.")
+
+		var zero zeropool.Pool[[]byte]
+		zeroClone := zero.Clone()
+		assertEqual(t, 0, len(zeroClone.Get()))
+	})
+
+	t.Run("PutAll pools all the items", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		items := make([][]byte, 100)
+		for i := range items {
+			items[i] = pool.Get()
+		}
+		pool.PutAll(items)
+
+		assertNoAllocs(t, len(items), func() {
+			for i := range items {
+				items[i] = pool.Get()
+			}
+			pool.PutAll(items)
+		})
+	})
+
+	t.Run("Freeze panics on new items", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		pool.Freeze()
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic.")
+			}
+		}()
+		pool.Get()
+	})
+
+	t.Run("TryGet does not create items", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		_, ok := pool.TryGet()
+		assertEqual(t, false, ok)
+	})
+
+	t.Run("GetWith uses the given function only when the pool is empty", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		assertEqual(t, 10, len(pool.GetWith(func() []byte { return make([]byte, 10) })))
+
+		reused := 0
+		for i := 0; i < 100; i++ {
+			pool.Put(make([]byte, 20))
+			if len(pool.GetWith(func() []byte { return make([]byte, 10) })) == 20 {
+				reused++
+			}
+		}
+		assertReused(t, reused)
+	})
+
+	t.Run("Swap returns a valid item", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		item := pool.Get()
+		for i := 0; i < 10; i++ {
+			item = pool.Swap(item)
+			assertEqual(t, 1024, len(item))
+		}
+		pool.Put(item)
+	})
+
+	t.Run("NewFromPrototype clones the prototype", func(t *testing.T) {
+		proto := []int{1, 2, 3}
+		pool := zeropool.NewFromPrototype(proto, func(s []int) []int { return append([]int(nil), s...) })
+
+		item := pool.Get()
+		assertEqual(t, proto, item)
+		item[0] = 42
+		assertEqualf(t, 1, proto[0], "Should not share state with the prototype.")
+	})
+
+	t.Run("GetFresh reports new items", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		item, fresh := pool.GetFresh()
+		assertEqual(t, 1024, len(item))
+		assertEqual(t, true, fresh)
+
+		reused := 0
+		for i := 0; i < 100; i++ {
+			pool.Put(item)
+			if item, fresh = pool.GetFresh(); !fresh {
+				reused++
+			}
+		}
+		assertReused(t, reused)
+	})
+
+	t.Run("Invalidate discards pooled items", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		pool.Put(make([]byte, 10))
+		pool.Put(make([]byte, 10))
+		pool.Invalidate()
+
+		assertEqual(t, 1024, len(pool.Get()))
+		assertEqual(t, 1024, len(pool.Get()))
+	})
+
+	t.Run("GetInto provides correct values", func(t *testing.T) {
+		pool := zeropool.New(func() [64]int { return [64]int{0: 1} })
+		var item [64]int
+		pool.GetInto(&item)
+		assertEqual(t, 1, item[0])
+
+		item[0] = 2
+		pool.Put(item)
+
+		var other [64]int
+		pool.GetInto(&other)
+		if other[0] != 1 && other[0] != 2 {
+			t.Errorf("Expected a new or pooled item, got %d", other[0])
+		}
+	})
+
+	t.Run("GetInto does not allocate", func(t *testing.T) {
+		pool := zeropool.New(func() [64]int { return [64]int{} })
+		var item [64]int
+		pool.GetInto(&item)
+		pool.Put(item)
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			pool.GetInto(&item)
+			pool.Put(item)
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
 }
 
 func BenchmarkZeropoolPool(b *testing.B) {
@@ -270,3 +444,36 @@ func assertEqualf(t *testing.T, expected, got interface{}, msg string, args ...a
 		t.Errorf(msg, args...)
 	}
 }
+
+// assertNoAllocs checks that f doesn't allocate, where puts is the number of items that f puts into sync.Pools.
+// The race detector makes sync.Pool drop a random quarter of the items put into it, which have to be allocated again,
+// so when it's enabled, f is only required to allocate less than once per put.
+func assertNoAllocs(t *testing.T, puts int, f func()) {
+	t.Helper()
+	allocs := testing.AllocsPerRun(1000, f)
+	if raceEnabled {
+		if allocs >= float64(puts) {
+			t.Errorf("Expected less than %d allocations per run, got %f.", puts, allocs)
+		}
+		return
+	}
+	assertEqualf(t, float64(0), allocs, "Should not allocate.")
+}
+
+// assertReused checks that some of the items put into a pool were handed out again, reused being how many of them were.
+// sync.Pool doesn't guarantee that Get returns the items put into it: the race detector makes it drop a random quarter of them,
+// and a GC may clear it, so not all of them are required to be reused.
+func assertReused(t *testing.T, reused int) {
+	t.Helper()
+	if reused == 0 {
+		t.Errorf("Expected pooled items to be reused.")
+	}
+}
+
+// skipIfDisabled skips the test when pooling is disabled, as it relies on the items being reused.
+func skipIfDisabled(t *testing.T) {
+	t.Helper()
+	if zeropool.Disabled() {
+		t.Skip("Pooling is disabled.")
+	}
+}
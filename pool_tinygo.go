@@ -0,0 +1,45 @@
+//go:build tinygo
+
+package zeropool
+
+import "sync"
+
+// maxFreelistLen is the maximum number of items held by each freelist on TinyGo, the items put when it's full are dropped.
+// The freelists are never cleared by the garbage collector, so this bounds the memory kept by a burst of Puts.
+const maxFreelistLen = 1024
+
+// syncPool is the pool that backs all the pools in this package.
+// On TinyGo, sync.Pool doesn't actually pool anything, so we use a mutex-guarded freelist instead.
+// Unlike sync.Pool, this freelist is never cleared by the garbage collector, so it's capped to maxFreelistLen items.
+type syncPool struct {
+	mtx   sync.Mutex
+	items []any
+}
+
+// Get takes an item from the freelist, or returns nil if it's empty.
+func (p *syncPool) Get() any {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	n := len(p.items)
+	if n == 0 {
+		return nil
+	}
+	item := p.items[n-1]
+	p.items[n-1] = nil
+	p.items = p.items[:n-1]
+	return item
+}
+
+// Put adds x to the freelist, unless it's full.
+func (p *syncPool) Put(x any) {
+	if x == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if len(p.items) < maxFreelistLen {
+		p.items = append(p.items, x)
+	}
+}
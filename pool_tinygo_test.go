@@ -0,0 +1,20 @@
+//go:build tinygo
+
+package zeropool
+
+import "testing"
+
+func TestSyncPoolIsBounded(t *testing.T) {
+	var p syncPool
+	for i := 0; i < maxFreelistLen+10; i++ {
+		p.Put(i)
+	}
+
+	got := 0
+	for p.Get() != nil {
+		got++
+	}
+	if got != maxFreelistLen {
+		t.Errorf("Expected %d items in the freelist, got %d.", maxFreelistLen, got)
+	}
+}
@@ -0,0 +1,28 @@
+package zeropool
+
+import (
+	"os"
+	"strconv"
+)
+
+// disabled is true when pooling is disabled, either by building with the zeropool_off build tag,
+// or by setting the ZEROPOOL_DISABLE environment variable to a true value, like ZEROPOOL_DISABLE=1.
+// The environment variable is only read once, when the program starts.
+// When disabled, the pools never hold any items: Get always creates a new item, and Put drops it.
+// It's meant to rule out pooling when hunting memory corruption bugs, or to mitigate an incident, without touching the call sites.
+//
+// Since the environment variable can only be read at runtime, disabled is a variable even in normal builds,
+// so each Put checks it, which costs a load and a well-predicted branch.
+var disabled = disabledByBuildTag || envBool("ZEROPOOL_DISABLE")
+
+// Disabled reports whether pooling is disabled, either by the zeropool_off build tag or by the ZEROPOOL_DISABLE environment variable.
+// It's meant for tests that assert that items are reused, which can't pass when pooling is disabled.
+func Disabled() bool {
+	return disabled
+}
+
+// envBool returns the boolean value of the given environment variable, it's false if the variable is not set or invalid.
+func envBool(name string) bool {
+	v, _ := strconv.ParseBool(os.Getenv(name))
+	return v
+}
@@ -0,0 +1,6 @@
+//go:build !zeropool_off
+
+package zeropool
+
+// disabledByBuildTag is true when the package is built with the zeropool_off build tag.
+const disabledByBuildTag = false
@@ -0,0 +1,52 @@
+package zeropool_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestDisabled(t *testing.T) {
+	if !zeropool.Disabled() {
+		// Run this test again in a new process, with pooling disabled by the environment variable.
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDisabled$", "-test.v")
+		cmd.Env = append(os.Environ(), "ZEROPOOL_DISABLE=1")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("Test with pooling disabled failed: %v\n%s", err, out)
+		}
+		return
+	}
+
+	t.Run("Pool creates new items", func(t *testing.T) {
+		var created int
+		pool := zeropool.New(func() int {
+			created++
+			return created
+		})
+		pool.Put(pool.Get())
+		_, ok := pool.TryGet()
+		assertEqualf(t, false, ok, "Should not pool items.")
+		assertEqual(t, 2, pool.Get())
+	})
+
+	t.Run("PointerPool creates new items", func(t *testing.T) {
+		var pool zeropool.PointerPool[int]
+		pool.Put(new(int))
+		_, ok := pool.TryGet()
+		assertEqualf(t, false, ok, "Should not pool items.")
+	})
+
+	t.Run("LRUPool evicts the items put", func(t *testing.T) {
+		var evicted []int
+		pool := zeropool.NewLRUPool(10, func() int { return -1 }, func(item int) {
+			evicted = append(evicted, item)
+		})
+		pool.Put(1)
+		_, ok := pool.TryGet()
+		assertEqualf(t, false, ok, "Should not pool items.")
+		assertEqualf(t, []int{1}, evicted, "Should evict the dropped items.")
+	})
+}
@@ -0,0 +1,56 @@
+// Package pools provides ready-made pools for the types that are most commonly pooled.
+// All of them are safe for concurrent use, and they drop the items that grew too big,
+// so a single huge item doesn't stay pooled forever.
+//
+// There's no pool for *strings.Builder: its Reset drops the buffer, since the strings it built reference it,
+// so pooling it would only save the allocation of the Builder struct itself.
+package pools
+
+import (
+	"bytes"
+
+	"github.com/colega/zeropool"
+)
+
+const (
+	// minSliceCap is the minimum capacity of the slices kept in Bytes and Int64s, smaller ones are dropped.
+	minSliceCap = 64
+	// maxRetainedBytes is the maximum size of the items that are kept in the pools, bigger ones are dropped.
+	maxRetainedBytes = 1 << 20
+	// maxRetainedMapLen is the maximum length of the maps kept in StringMaps, bigger ones are dropped.
+	maxRetainedMapLen = 1024
+)
+
+// Bytes is a pool of byte slices, use Bytes.GetAppendable() to get a slice ready to be appended to.
+var Bytes = zeropool.NewSlicePoolWithCap(
+	minSliceCap,
+	zeropool.WithMinCap[byte](minSliceCap),
+	zeropool.WithPutPolicy(func(b []byte) ([]byte, bool) { return b, cap(b) <= maxRetainedBytes }),
+)
+
+// Int64s is a pool of int64 slices, use Int64s.GetZeroed(n) to get a zeroed scratch vector.
+var Int64s = zeropool.NewSlicePoolWithCap(
+	minSliceCap,
+	zeropool.WithMinCap[int64](minSliceCap),
+	zeropool.WithPutPolicy(func(s []int64) ([]int64, bool) { return s, cap(s) <= maxRetainedBytes/8 }),
+)
+
+// StringMaps is a pool of map[string]string, the maps are emptied when they're put back into the pool.
+var StringMaps = zeropool.New(
+	func() map[string]string { return make(map[string]string) },
+	zeropool.WithPutPolicy(func(m map[string]string) (map[string]string, bool) {
+		if len(m) > maxRetainedMapLen {
+			return nil, false
+		}
+		for k := range m {
+			delete(m, k)
+		}
+		return m, true
+	}),
+)
+
+// Buffers is a pool of *bytes.Buffer, the buffers are reset when they're put back into the pool.
+var Buffers = zeropool.NewPointerPool(
+	func() *bytes.Buffer { return new(bytes.Buffer) },
+	func(b *bytes.Buffer) { b.Reset() },
+)
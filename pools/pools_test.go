@@ -0,0 +1,76 @@
+package pools_test
+
+import (
+	"testing"
+
+	"github.com/colega/zeropool"
+	"github.com/colega/zeropool/pools"
+)
+
+func TestBytes(t *testing.T) {
+	b := append(pools.Bytes.GetAppendable(), "hello"...)
+	if string(b) != "hello" {
+		t.Errorf("Expected hello, got %q.", b)
+	}
+	pools.Bytes.Put(b)
+
+	if zeropool.Disabled() {
+		t.Skip("Pooling is disabled.")
+	}
+	allocs := testing.AllocsPerRun(1000, func() {
+		b := append(pools.Bytes.GetAppendable(), "hello"...)
+		pools.Bytes.Put(b)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected the slices to be reused, got %f allocations per run.", allocs)
+	}
+}
+
+func TestInt64s(t *testing.T) {
+	s := pools.Int64s.GetZeroed(10)
+	for i, v := range s {
+		if v != 0 {
+			t.Errorf("Expected element %d to be zero, got %d.", i, v)
+		}
+	}
+	pools.Int64s.Put(s)
+
+	if zeropool.Disabled() {
+		t.Skip("Pooling is disabled.")
+	}
+	allocs := testing.AllocsPerRun(1000, func() {
+		s := pools.Int64s.GetZeroed(10)
+		pools.Int64s.Put(s)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected the slices to be reused, got %f allocations per run.", allocs)
+	}
+}
+
+func TestStringMaps(t *testing.T) {
+	m := pools.StringMaps.Get()
+	m["foo"] = "bar"
+	pools.StringMaps.Put(m)
+
+	for i := 0; i < 3; i++ {
+		m := pools.StringMaps.Get()
+		if len(m) != 0 {
+			t.Errorf("Expected an empty map, got %v.", m)
+		}
+		pools.StringMaps.Put(m)
+	}
+}
+
+func TestBuffers(t *testing.T) {
+	b := pools.Buffers.Get()
+	b.WriteString("hello")
+	pools.Buffers.Put(b)
+
+	for i := 0; i < 3; i++ {
+		b := pools.Buffers.Get()
+		if b.Len() != 0 {
+			t.Errorf("Expected an empty buffer, got %q.", b.String())
+		}
+		pools.Buffers.Put(b)
+	}
+}
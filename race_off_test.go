@@ -0,0 +1,6 @@
+//go:build !race
+
+package zeropool_test
+
+// raceEnabled is true when the race detector is enabled, which makes sync.Pool drop a quarter of the items put into it.
+const raceEnabled = false
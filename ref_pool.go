@@ -0,0 +1,66 @@
+package zeropool
+
+import "sync/atomic"
+
+// RefPool is a pool of reference-counted items, meant for items that are shared by several consumers.
+// Get returns a *Ref holding one reference, each consumer can Acquire its own reference,
+// and the item is returned to the pool once all the references are released.
+//
+// Zero value of RefPool[T] is valid, and it will hold zero values of T if nothing is pooled.
+type RefPool[T any] struct {
+	refs PointerPool[Ref[T]]
+	item func() T
+}
+
+// NewRefPool creates a new RefPool[T] with the given function to create new items.
+// A RefPool must not be copied after first use.
+func NewRefPool[T any](item func() T) RefPool[T] {
+	return RefPool[T]{item: item}
+}
+
+// Get returns a reference to an item from the pool, creating a new one if necessary.
+// The returned reference has to be released by calling Release.
+// Get may be called concurrently from multiple goroutines.
+func (p *RefPool[T]) Get() *Ref[T] {
+	r := p.refs.Get()
+	if r.pool == nil {
+		// This is a new Ref.
+		r.pool = p
+		if p.item != nil {
+			r.value = p.item()
+		}
+	}
+	r.refs.Store(1)
+	return r
+}
+
+// Ref is a reference-counted item from a RefPool.
+type Ref[T any] struct {
+	pool  *RefPool[T]
+	refs  atomic.Int32
+	value T
+}
+
+// Value returns the referenced item, it must not be used after releasing the reference.
+func (r *Ref[T]) Value() T {
+	return r.value
+}
+
+// Acquire adds a reference to the item, which has to be released by calling Release.
+// It panics if the item was already returned to the pool.
+func (r *Ref[T]) Acquire() {
+	if r.refs.Add(1) <= 1 {
+		panic("zeropool: Acquire called on a released Ref")
+	}
+}
+
+// Release releases a reference to the item, and returns it to the pool when it was the last one.
+// It panics if the item was already returned to the pool.
+func (r *Ref[T]) Release() {
+	switch refs := r.refs.Add(-1); {
+	case refs == 0:
+		r.pool.refs.Put(r)
+	case refs < 0:
+		panic("zeropool: Release called on a released Ref")
+	}
+}
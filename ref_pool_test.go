@@ -0,0 +1,72 @@
+package zeropool_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestRefPool(t *testing.T) {
+	t.Run("provides correct values", func(t *testing.T) {
+		pool := zeropool.NewRefPool(func() []byte { return make([]byte, 1024) })
+		ref := pool.Get()
+		assertEqual(t, 1024, len(ref.Value()))
+		ref.Release()
+
+		ref = pool.Get()
+		assertEqual(t, 1024, len(ref.Value()))
+		ref.Release()
+	})
+
+	t.Run("shared by several consumers", func(t *testing.T) {
+		pool := zeropool.NewRefPool(func() []byte { return make([]byte, 1024) })
+		ref := pool.Get()
+
+		const consumers = 10
+		wg := sync.WaitGroup{}
+		wg.Add(consumers)
+		for i := 0; i < consumers; i++ {
+			ref.Acquire()
+			go func() {
+				defer wg.Done()
+				defer ref.Release()
+				if len(ref.Value()) != 1024 {
+					panic("wrong value")
+				}
+			}()
+		}
+		ref.Release()
+		wg.Wait()
+	})
+
+	t.Run("panics when released too many times", func(t *testing.T) {
+		var pool zeropool.RefPool[[]byte]
+		ref := pool.Get()
+		ref.Acquire()
+		ref.Release()
+		ref.Release()
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic.")
+			}
+		}()
+		ref.Release()
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.NewRefPool(func() []byte { return make([]byte, 1024) })
+		ref := pool.Get()
+		ref.Release()
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			ref := pool.Get()
+			ref.Acquire()
+			ref.Release()
+			ref.Release()
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
+}
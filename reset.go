@@ -0,0 +1,61 @@
+package zeropool
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// DeepReset returns a put policy that resets the items using reflection, to be used with WithPutPolicy:
+// scalars, pointers, interfaces, channels and functions are set to their zero value,
+// slices are truncated to zero length keeping their capacity, maps are cleared,
+// and structs and arrays are reset field by field.
+// Unexported fields are set to their zero value instead of being reset, as truncating them could break the invariants
+// of the types that own them, like a strings.Builder whose buffer is referenced by the strings it built.
+// If T is a pointer, the value it points to is reset instead.
+//
+// Note that the elements past the length of a truncated slice are not cleared, so they keep anything they reference.
+// Reflection is slow compared to a hand-written reset function, so it's meant for items that are not in hot paths.
+func DeepReset[T any]() func(T) (T, bool) {
+	return func(item T) (T, bool) {
+		v := reflect.ValueOf(&item).Elem()
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return item, true
+			}
+			v = v.Elem()
+		}
+		deepReset(v)
+		return item, true
+	}
+}
+
+// deepReset resets v, which must be settable, see DeepReset.
+func deepReset(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Field(i)
+			if !f.CanSet() {
+				// Unexported fields can't be set through reflection, so we access them through their address.
+				f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+				f.Set(reflect.Zero(f.Type()))
+				continue
+			}
+			deepReset(f)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			deepReset(v.Index(i))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			v.SetLen(0)
+		}
+	case reflect.Map:
+		for it := v.MapRange(); it.Next(); {
+			v.SetMapIndex(it.Key(), reflect.Value{})
+		}
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
@@ -0,0 +1,79 @@
+package zeropool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+type resettable struct {
+	Name    string
+	Count   int
+	Buf     []byte
+	Labels  map[string]string
+	Next    *resettable
+	Nested  struct{ Values [2]float64 }
+	private []int
+}
+
+func TestDeepReset(t *testing.T) {
+	t.Run("resets all fields", func(t *testing.T) {
+		reset := zeropool.DeepReset[resettable]()
+		item := resettable{
+			Name:    "name",
+			Count:   42,
+			Buf:     make([]byte, 10, 100),
+			Labels:  map[string]string{"a": "b"},
+			Next:    &resettable{},
+			private: []int{1, 2, 3},
+		}
+		item.Nested.Values = [2]float64{1, 2}
+
+		item, ok := reset(item)
+		assertEqual(t, true, ok)
+		assertEqual(t, "", item.Name)
+		assertEqual(t, 0, item.Count)
+		assertEqual(t, 0, len(item.Buf))
+		assertEqualf(t, 100, cap(item.Buf), "Should keep the capacity of slices.")
+		assertEqual(t, 0, len(item.Labels))
+		assertEqualf(t, false, item.Labels == nil, "Should keep the maps.")
+		assertEqual(t, (*resettable)(nil), item.Next)
+		assertEqual(t, [2]float64{}, item.Nested.Values)
+		assertEqual(t, 0, len(item.private))
+	})
+
+	t.Run("does not truncate unexported fields", func(t *testing.T) {
+		type item struct{ sb strings.Builder }
+		reset := zeropool.DeepReset[*item]()
+		it := &item{}
+		it.sb.WriteString("hello")
+		s := it.sb.String()
+
+		reset(it)
+		it.sb.WriteString("XXXXX")
+		assertEqualf(t, "hello", s, "Should not overwrite the strings built before the reset.")
+		assertEqual(t, "XXXXX", it.sb.String())
+	})
+
+	t.Run("resets the value pointed to", func(t *testing.T) {
+		reset := zeropool.DeepReset[*resettable]()
+		item := &resettable{Name: "name", Buf: make([]byte, 10)}
+
+		reset(item)
+		assertEqual(t, "", item.Name)
+		assertEqual(t, 0, len(item.Buf))
+
+		_, ok := reset(nil)
+		assertEqual(t, true, ok)
+	})
+
+	t.Run("can be used as a put policy", func(t *testing.T) {
+		pool := zeropool.New(
+			func() []byte { return make([]byte, 0, 1024) },
+			zeropool.WithPutPolicy(zeropool.DeepReset[[]byte]()),
+		)
+		pool.Put(make([]byte, 100, 1024))
+		assertEqual(t, 0, len(pool.Get()))
+	})
+}
@@ -0,0 +1,106 @@
+package zeropool
+
+import (
+	"context"
+	"sync"
+)
+
+// Group tracks the items borrowed from a Pool through it, so they can be returned all at once with ReleaseAll.
+// It's meant for functions that borrow a variable number of items:
+//
+//	g := pool.Group()
+//	defer g.ReleaseAll()
+//	a := g.Get()
+//	b := g.Get()
+type Group[T any] struct {
+	pool *Pool[T]
+
+	mtx   sync.Mutex
+	items []T
+	// released is set once the group can't track items anymore, see Scope.
+	released bool
+}
+
+// Group creates a new Group that borrows items from p.
+func (p *Pool[T]) Group() *Group[T] {
+	return &Group[T]{pool: p}
+}
+
+// Get returns an item from the pool, which will be returned to the pool by ReleaseAll.
+// Get may be called concurrently from multiple goroutines.
+func (g *Group[T]) Get() T {
+	item := g.pool.Get()
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if !g.released {
+		g.items = append(g.items, item)
+	}
+	return item
+}
+
+// ReleaseAll returns all the items borrowed through the group to the pool.
+// None of them can be used after calling ReleaseAll, but the group can be used to borrow more items.
+func (g *Group[T]) ReleaseAll() {
+	g.release(false)
+}
+
+// release returns all the tracked items to the pool.
+// If final is true, the group stops tracking the items borrowed after this call.
+func (g *Group[T]) release(final bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.pool.PutAll(g.items)
+	var zero T
+	for i := range g.items {
+		g.items[i] = zero
+	}
+	g.items = g.items[:0]
+
+	if final {
+		g.released = true
+		g.items = nil
+	}
+}
+
+// Scope tracks the items borrowed from a Pool through it, and returns them all to the pool once its context is done.
+// It's meant for request-scoped borrowing, where the items can't outlive the request.
+type Scope[T any] struct {
+	group Group[T]
+	// stop is closed by Release, so the goroutine waiting for the context to be done can exit.
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// Scoped creates a new Scope that borrows items from p,
+// and returns all of them to p when ctx is done.
+// The items borrowed through the scope must not be used after ctx is done.
+// If ctx is never done, the items are only returned when Release is called.
+func (p *Pool[T]) Scoped(ctx context.Context) *Scope[T] {
+	s := &Scope[T]{group: Group[T]{pool: p}, stop: make(chan struct{})}
+	if done := ctx.Done(); done != nil {
+		go func() {
+			select {
+			case <-done:
+				s.Release()
+			case <-s.stop:
+			}
+		}()
+	}
+	return s
+}
+
+// Get returns an item from the pool, which will be returned to the pool when the scope is released.
+// If the scope was already released, the item isn't tracked, and the caller is responsible for putting it back.
+// Get may be called concurrently from multiple goroutines.
+func (s *Scope[T]) Get() T {
+	return s.group.Get()
+}
+
+// Release returns all the items borrowed through the scope to the pool.
+// It's called automatically when the scope's context is done, and it's safe to call it more than once.
+func (s *Scope[T]) Release() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.group.release(true)
+}
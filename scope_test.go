@@ -0,0 +1,114 @@
+package zeropool_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/colega/zeropool"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("returns items when context is done", func(t *testing.T) {
+		skipIfDisabled(t)
+		const borrowed = 100
+		var created int
+		pool := zeropool.New(func() int {
+			created++
+			return created
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		scope := pool.Scoped(ctx)
+		for i := 0; i < borrowed; i++ {
+			assertEqual(t, i+1, scope.Get())
+		}
+		cancel()
+
+		// Release happens asynchronously, wait until we get one of the borrowed items back.
+		deadline := time.Now().Add(time.Second)
+		for pool.Get() > borrowed {
+			if time.Now().After(deadline) {
+				t.Fatalf("Borrowed items were not returned to the pool.")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	t.Run("release can be called more than once", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		scope := pool.Scoped(context.Background())
+		assertEqual(t, 1024, len(scope.Get()))
+		scope.Release()
+		scope.Release()
+
+		// Items borrowed after release are not tracked, but are still valid.
+		assertEqual(t, 1024, len(scope.Get()))
+	})
+
+	t.Run("release stops waiting for the context", func(t *testing.T) {
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		before := runtime.NumGoroutine()
+		scope := pool.Scoped(ctx)
+		scope.Get()
+		scope.Release()
+
+		// The goroutine waiting for the context exits asynchronously, wait until it's gone.
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Fatalf("The scope's goroutine didn't exit after Release.")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("releases all borrowed items", func(t *testing.T) {
+		skipIfDisabled(t)
+		const borrowed = 100
+		var created, put int
+		pool := zeropool.New(
+			func() int {
+				created++
+				return created
+			},
+			zeropool.WithPutPolicy(func(item int) (int, bool) {
+				put++
+				return item, true
+			}),
+		)
+
+		g := pool.Group()
+		for i := 0; i < borrowed; i++ {
+			assertEqual(t, i+1, g.Get())
+		}
+		g.ReleaseAll()
+		assertEqual(t, borrowed, put)
+
+		// Items borrowed after ReleaseAll are tracked too.
+		g.Get()
+		g.ReleaseAll()
+		assertEqual(t, borrowed+1, put)
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		pool := zeropool.New(func() []byte { return make([]byte, 1024) })
+		g := pool.Group()
+		borrow := func() {
+			for i := 0; i < 10; i++ {
+				g.Get()
+			}
+			g.ReleaseAll()
+		}
+		borrow()
+
+		assertNoAllocs(t, 10, borrow)
+	})
+}
@@ -0,0 +1,127 @@
+package zeropool
+
+// SlicePool is a pool of slices of E, which hands them out with the capacity that the caller needs.
+//
+// Zero value of SlicePool[E] is valid.
+type SlicePool[E any] struct {
+	pool Pool[[]E]
+	// newCap is the minimum capacity of the slices created when the pool is empty.
+	newCap int
+}
+
+// NewSlicePool creates a new SlicePool[E] with the given options.
+// A SlicePool must not be copied after first use.
+func NewSlicePool[E any](opts ...Option[[]E]) SlicePool[E] {
+	return SlicePool[E]{pool: New[[]E](nil, opts...)}
+}
+
+// NewSlicePoolWithCap creates a new SlicePool[E] that creates slices with a capacity of at least capacity,
+// and the given options.
+// It's meant for pools that are mostly used with GetAppendable, which would otherwise create slices with no capacity.
+// A SlicePool must not be copied after first use.
+func NewSlicePoolWithCap[E any](capacity int, opts ...Option[[]E]) SlicePool[E] {
+	return SlicePool[E]{pool: New[[]E](nil, opts...), newCap: capacity}
+}
+
+// GetAtLeast returns an empty slice with a capacity of at least n.
+// The capacity of the pooled slice is preserved, so it can be larger than n, which is what append-heavy callers want.
+// If the pooled slice is smaller than n, it's dropped and a new one is allocated.
+// GetAtLeast may be called concurrently from multiple goroutines.
+func (p *SlicePool[E]) GetAtLeast(n int) []E {
+	s := p.pool.Get()
+	if cap(s) < n || cap(s) == 0 {
+		if n < p.newCap {
+			n = p.newCap
+		}
+		return make([]E, 0, n)
+	}
+	return s[:0]
+}
+
+// GetAppendable returns an empty slice with the capacity of the pooled one, ready to be appended to.
+// GetAppendable may be called concurrently from multiple goroutines.
+func (p *SlicePool[E]) GetAppendable() []E {
+	return p.GetAtLeast(0)
+}
+
+// GetExact returns a slice with a length of exactly n, which is what index-heavy callers want.
+// The contents of the returned slice are not cleared, so they may hold values from its previous use.
+// GetExact may be called concurrently from multiple goroutines.
+func (p *SlicePool[E]) GetExact(n int) []E {
+	return p.GetAtLeast(n)[:n]
+}
+
+// GetZeroed returns a slice with a length of exactly n, with all its elements set to their zero value.
+// It's meant for scratch vectors, like []float64 accumulators, that need to start from zero.
+// GetZeroed may be called concurrently from multiple goroutines.
+func (p *SlicePool[E]) GetZeroed(n int) []E {
+	s := p.GetExact(n)
+	var zero E
+	for i := range s {
+		s[i] = zero
+	}
+	return s
+}
+
+// Append appends src to dst like the built-in append, but when dst needs to grow,
+// the new slice is taken from the pool, and dst is put back into it.
+// Like with append, the result must be used instead of dst after calling Append.
+// Append may be called concurrently from multiple goroutines.
+func (p *SlicePool[E]) Append(dst []E, src ...E) []E {
+	n := len(dst) + len(src)
+	if n <= cap(dst) {
+		return append(dst, src...)
+	}
+	size := 2 * cap(dst)
+	if size < n {
+		size = n
+	}
+	grown := append(p.GetAtLeast(size), dst...)
+	grown = append(grown, src...)
+	// src may be a part of dst, so dst can only be put back once src was copied.
+	p.Put(dst)
+	return grown
+}
+
+// Put adds a slice to the pool, regardless of its length.
+// Slices with no capacity are not pooled.
+func (p *SlicePool[E]) Put(s []E) {
+	if cap(s) == 0 {
+		return
+	}
+	p.pool.Put(s[:0])
+}
+
+// SlicesPool is a pool of slices of slices of E, like [][]byte, which recycles both the outer slices and the inner ones.
+//
+// Zero value of SlicesPool[E] is valid.
+type SlicesPool[E any] struct {
+	outer SlicePool[[]E]
+	inner SlicePool[E]
+}
+
+// Get returns an empty outer slice with a capacity of at least n.
+// Its elements should be obtained from GetInner, as they will be pooled too when the outer slice is put back.
+// Get may be called concurrently from multiple goroutines.
+func (p *SlicesPool[E]) Get(n int) [][]E {
+	return p.outer.GetAtLeast(n)
+}
+
+// GetInner returns an empty inner slice with a capacity of at least n.
+// GetInner may be called concurrently from multiple goroutines.
+func (p *SlicesPool[E]) GetInner(n int) []E {
+	return p.inner.GetAtLeast(n)
+}
+
+// Put adds the inner slices of s to the pool, and then the outer slice s itself.
+// The outer slice is cleared, so it doesn't retain references to the inner slices while it's pooled.
+func (p *SlicesPool[E]) Put(s [][]E) {
+	for _, inner := range s {
+		p.inner.Put(inner)
+	}
+	s = s[:cap(s)]
+	for i := range s {
+		s[i] = nil
+	}
+	p.outer.Put(s)
+}
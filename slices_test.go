@@ -0,0 +1,146 @@
+package zeropool_test
+
+import (
+	"testing"
+
+	"github.com/colega/zeropool"
+)
+
+func TestSlicePool(t *testing.T) {
+	t.Run("GetAtLeast preserves pooled capacity", func(t *testing.T) {
+		var pool zeropool.SlicePool[byte]
+		s := pool.GetAtLeast(100)
+		assertEqual(t, 0, len(s))
+		assertEqual(t, 100, cap(s))
+
+		pool.Put(make([]byte, 10, 1000))
+		s = pool.GetAtLeast(100)
+		assertEqual(t, 0, len(s))
+		if c := cap(s); c != 100 && c != 1000 {
+			t.Errorf("Expected capacity 100 or 1000, got %d", c)
+		}
+	})
+
+	t.Run("GetAtLeast does not return smaller slices", func(t *testing.T) {
+		var pool zeropool.SlicePool[byte]
+		pool.Put(make([]byte, 10))
+		s := pool.GetAtLeast(100)
+		assertEqual(t, 0, len(s))
+		assertEqual(t, 100, cap(s))
+	})
+
+	t.Run("GetAppendable returns empty slices with pooled capacity", func(t *testing.T) {
+		var pool zeropool.SlicePool[byte]
+		assertEqual(t, 0, cap(pool.GetAppendable()))
+
+		pool.Put(make([]byte, 10, 1000))
+		s := pool.GetAppendable()
+		assertEqual(t, 0, len(s))
+		if c := cap(s); c != 0 && c != 1000 {
+			t.Errorf("Expected capacity 0 or 1000, got %d", c)
+		}
+	})
+
+	t.Run("NewSlicePoolWithCap creates slices with capacity", func(t *testing.T) {
+		pool := zeropool.NewSlicePoolWithCap[byte](64)
+		assertEqual(t, 64, cap(pool.GetAppendable()))
+		assertEqual(t, 100, cap(pool.GetAtLeast(100)))
+	})
+
+	t.Run("GetExact returns requested length", func(t *testing.T) {
+		var pool zeropool.SlicePool[int64]
+		pool.Put(make([]int64, 1000))
+		assertEqual(t, 10, len(pool.GetExact(10)))
+		assertEqual(t, 2000, len(pool.GetExact(2000)))
+	})
+
+	t.Run("Append repools the replaced slice", func(t *testing.T) {
+		var pool zeropool.SlicePool[byte]
+		s := pool.GetAtLeast(4)
+		s = pool.Append(s, 'a', 'b', 'c')
+		assertEqual(t, 4, cap(s))
+		s = pool.Append(s, 'd', 'e')
+		assertEqual(t, "abcde", string(s))
+		assertEqual(t, 8, cap(s))
+
+		// The replaced slice is the only one pooled, unless the race detector dropped it.
+		if c := cap(pool.GetAppendable()); c != 0 && c != 4 {
+			t.Errorf("Expected capacity 0 or 4, got %d", c)
+		}
+	})
+
+	t.Run("Append copies src when it's a part of dst", func(t *testing.T) {
+		var pool zeropool.SlicePool[byte]
+		s := append(pool.GetAtLeast(4), "abcd"...)
+		s = pool.Append(s, s[:2]...)
+		assertEqual(t, "abcdab", string(s))
+
+		// The replaced slice is pooled, and the next user can overwrite it without affecting s.
+		reused := pool.GetExact(4)
+		copy(reused, "wxyz")
+		assertEqual(t, "abcdab", string(s))
+	})
+
+	t.Run("GetZeroed returns zeroed scratch vectors", func(t *testing.T) {
+		var floats zeropool.SlicePool[float64]
+		s := floats.GetExact(10)
+		for i := range s {
+			s[i] = float64(i)
+		}
+		floats.Put(s)
+		assertEqual(t, make([]float64, 10), floats.GetZeroed(10))
+
+		var ints zeropool.SlicePool[int64]
+		assertEqual(t, make([]int64, 5), ints.GetZeroed(5))
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		var pool zeropool.SlicePool[byte]
+		s := pool.GetAtLeast(1024)
+		pool.Put(s)
+
+		allocs := testing.AllocsPerRun(1000, func() {
+			s := pool.GetExact(1024)
+			pool.Put(s)
+		})
+		assertEqualf(t, float64(0), allocs, "Should not allocate.")
+	})
+}
+
+func TestSlicesPool(t *testing.T) {
+	t.Run("recycles outer and inner slices", func(t *testing.T) {
+		var pool zeropool.SlicesPool[byte]
+		s := pool.Get(10)
+		assertEqual(t, 0, len(s))
+		assertEqual(t, 10, cap(s))
+		for i := 0; i < 10; i++ {
+			s = append(s, append(pool.GetInner(100), byte(i)))
+		}
+		pool.Put(s)
+
+		s = pool.Get(10)
+		assertEqual(t, 0, len(s))
+		for _, inner := range s[:cap(s)] {
+			assertEqualf(t, []byte(nil), inner, "Outer slice should be cleared.")
+		}
+		inner := pool.GetInner(100)
+		assertEqual(t, 0, len(inner))
+		assertEqual(t, 100, cap(inner))
+	})
+
+	t.Run("does not allocate", func(t *testing.T) {
+		skipIfDisabled(t)
+		var pool zeropool.SlicesPool[byte]
+		fill := func() {
+			s := pool.Get(10)
+			for i := 0; i < 10; i++ {
+				s = append(s, pool.GetInner(100))
+			}
+			pool.Put(s)
+		}
+		fill()
+
+		assertNoAllocs(t, 11, fill)
+	})
+}
@@ -0,0 +1,29 @@
+package zeropool
+
+import (
+	"bytes"
+	"io"
+)
+
+// Template is a template that can be rendered by RenderPooled,
+// both *html/template.Template and *text/template.Template implement it.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// renderBuffers holds the buffers used by RenderPooled.
+var renderBuffers Pool[[]byte]
+
+// RenderPooled executes the template t with the given data into a pooled buffer.
+// The returned release func puts the buffer back into the pool, the returned bytes must not be used after calling it.
+// If the template execution fails, the buffer is returned to the pool immediately, and release is a no-op.
+func RenderPooled(t Template, data any) (rendered []byte, release func(), err error) {
+	buf := bytes.NewBuffer(renderBuffers.Get()[:0])
+	if err := t.Execute(buf, data); err != nil {
+		renderBuffers.Put(buf.Bytes()[:0])
+		return nil, func() {}, err
+	}
+
+	rendered = buf.Bytes()
+	return rendered, func() { renderBuffers.Put(rendered[:0]) }, nil
+}
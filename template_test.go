@@ -0,0 +1,45 @@
+package zeropool_test
+
+import (
+	"html/template"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/colega/zeropool"
+)
+
+func TestRenderPooled(t *testing.T) {
+	t.Run("renders the template", func(t *testing.T) {
+		tmpl := template.Must(template.New("test").Parse(`<p>{{.}}</p>`))
+
+		rendered, release, err := zeropool.RenderPooled(tmpl, "a & b")
+		assertEqual(t, nil, err)
+		assertEqual(t, "<p>a &amp; b</p>", string(rendered))
+		release()
+
+		rendered, release, err = zeropool.RenderPooled(tmpl, "c")
+		assertEqual(t, nil, err)
+		assertEqual(t, "<p>c</p>", string(rendered))
+		release()
+	})
+
+	t.Run("renders text templates", func(t *testing.T) {
+		tmpl := texttemplate.Must(texttemplate.New("test").Parse(`<p>{{.}}</p>`))
+
+		rendered, release, err := zeropool.RenderPooled(tmpl, "a & b")
+		assertEqual(t, nil, err)
+		assertEqual(t, "<p>a & b</p>", string(rendered))
+		release()
+	})
+
+	t.Run("returns template errors", func(t *testing.T) {
+		tmpl := template.Must(template.New("test").Parse(`{{.Missing}}`))
+
+		rendered, release, err := zeropool.RenderPooled(tmpl, 42)
+		if err == nil {
+			t.Fatalf("Expected an error, got nil.")
+		}
+		assertEqual(t, 0, len(rendered))
+		release()
+	})
+}
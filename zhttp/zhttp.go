@@ -0,0 +1,35 @@
+// Package zhttp provides request-scoped borrowing from zeropool pools for net/http handlers.
+// It's a separate package so the core zeropool package doesn't depend on net/http.
+package zhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/colega/zeropool"
+)
+
+// scopeContextKey is the context key of the zeropool.Scope[T] added by Middleware.
+type scopeContextKey[T any] struct{}
+
+// Middleware returns a middleware that adds a zeropool.Scope borrowing items from pool to each request's context,
+// and returns all the items borrowed through it to the pool once the handler returns.
+// Handlers obtain the scope with FromContext, and must not use the borrowed items after they return,
+// which includes the goroutines they start.
+func Middleware[T any](pool *zeropool.Pool[T]) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The scope is released when the handler returns, not when the request's context is done.
+			scope := pool.Scoped(context.Background())
+			defer scope.Release()
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), scopeContextKey[T]{}, scope)))
+		})
+	}
+}
+
+// FromContext returns the zeropool.Scope[T] added to ctx by Middleware, or nil if there's none.
+// If several middlewares for pools of the same type are chained, the innermost one's scope is returned.
+func FromContext[T any](ctx context.Context) *zeropool.Scope[T] {
+	s, _ := ctx.Value(scopeContextKey[T]{}).(*zeropool.Scope[T])
+	return s
+}
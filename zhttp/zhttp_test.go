@@ -0,0 +1,51 @@
+package zhttp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/colega/zeropool"
+	"github.com/colega/zeropool/zhttp"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("releases borrowed items when the handler returns", func(t *testing.T) {
+		if zeropool.Disabled() {
+			t.Skip("Pooling is disabled.")
+		}
+		const borrowed = 100
+		var created, put int
+		pool := zeropool.New(
+			func() int {
+				created++
+				return created
+			},
+			zeropool.WithPutPolicy(func(item int) (int, bool) {
+				put++
+				return item, true
+			}),
+		)
+
+		handler := zhttp.Middleware(&pool)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := zhttp.FromContext[int](r.Context())
+			for i := 0; i < borrowed; i++ {
+				scope.Get()
+			}
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		if created != borrowed {
+			t.Errorf("Expected %d items to be created, got %d.", borrowed, created)
+		}
+		if put != borrowed {
+			t.Errorf("Expected %d items to be put back, got %d.", borrowed, put)
+		}
+	})
+
+	t.Run("FromContext returns nil without middleware", func(t *testing.T) {
+		if scope := zhttp.FromContext[int](context.Background()); scope != nil {
+			t.Errorf("Expected no scope, got %v.", scope)
+		}
+	})
+}